@@ -0,0 +1,5 @@
+data "replicate_account" "current" {}
+
+output "deployment_id" {
+  value = "${data.replicate_account.current.username}/terraform-example"
+}
@@ -0,0 +1,12 @@
+# Read a deployment managed by another team's configuration
+data "replicate_deployment" "shared" {
+  deployment = "acme/image-generator"
+}
+
+output "shared_version" {
+  value = data.replicate_deployment.shared.version
+}
+
+output "shared_release" {
+  value = data.replicate_deployment.shared.current_release.number
+}
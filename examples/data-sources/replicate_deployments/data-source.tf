@@ -0,0 +1,29 @@
+data "replicate_deployments" "all" {}
+
+output "deployment_names" {
+  value = [for d in data.replicate_deployments.all.deployments : "${d.owner}/${d.name}"]
+}
+
+# Find deployments that keep GPU instances running
+data "replicate_deployments" "a100" {
+  hardware = "gpu-a100-large"
+}
+
+output "always_on_a100_deployments" {
+  value = [for d in data.replicate_deployments.a100.deployments : d.name if d.min_instances > 0]
+}
+
+# Render an import block for every deployment, for bulk importing with
+# terraform plan -generate-config-out. Resource names can't start with a
+# digit, so they're prefixed with "d_". Replacing other characters with "_"
+# can make two names collide, so check the output for duplicates.
+output "deployment_import_blocks" {
+  value = join("\n", [
+    for d in data.replicate_deployments.all.deployments : <<-EOT
+      import {
+        to = replicate_deployment.d_${replace(d.name, "/[^a-zA-Z0-9_-]/", "_")}
+        id = "${d.id}"
+      }
+    EOT
+  ])
+}
@@ -3,3 +3,18 @@ data "replicate_hardware" "available" {}
 output "hardware_options" {
   value = data.replicate_hardware.available.hardware
 }
+
+# Look up a single hardware option by name
+data "replicate_hardware" "t4" {
+  name = "Nvidia T4 GPU"
+}
+
+output "t4_sku" {
+  value = data.replicate_hardware.t4.selected.sku
+}
+
+# List every A40 GPU option
+data "replicate_hardware" "a40" {
+  name_regex = "A40"
+  gpu_only   = true
+}
@@ -0,0 +1,12 @@
+data "replicate_model" "hello_world" {
+  model = "replicate/hello-world"
+}
+
+resource "replicate_deployment" "terraform-example" {
+  name          = "terraform-example"
+  model         = data.replicate_model.hello_world.id
+  version       = data.replicate_model.hello_world.latest_version.id
+  hardware      = "cpu"
+  min_instances = 0
+  max_instances = 1
+}
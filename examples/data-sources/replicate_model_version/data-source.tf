@@ -1,3 +1,22 @@
 data "replicate_model_version" "sdxl" {
   model = "stability-ai/sdxl"
 }
+
+# Select the most recent version built with Cog 0.9 or later
+data "replicate_model_version" "sdxl_cog_0_9" {
+  model             = "stability-ai/sdxl"
+  cog_version_regex = "^0\\.(9|[1-9][0-9])\\."
+  created_after     = "2024-01-01T00:00:00Z"
+  most_recent       = true
+}
+
+# Check that the selected version still accepts the inputs an app sends
+check "sdxl_inputs" {
+  assert {
+    condition = alltrue([
+      for name in ["prompt", "num_outputs"] :
+      contains(data.replicate_model_version.sdxl_cog_0_9.version.inputs[*].name, name)
+    ])
+    error_message = "The selected SDXL version no longer accepts the prompt and num_outputs inputs."
+  }
+}
@@ -0,0 +1,11 @@
+data "replicate_webhook_secret" "default" {}
+
+# Store the signing key where webhook consumers can read it
+resource "aws_secretsmanager_secret" "replicate_webhook" {
+  name = "replicate-webhook-signing-key"
+}
+
+resource "aws_secretsmanager_secret_version" "replicate_webhook" {
+  secret_id     = aws_secretsmanager_secret.replicate_webhook.id
+  secret_string = data.replicate_webhook_secret.default.key
+}
@@ -0,0 +1,15 @@
+data "replicate_webhook_secret" "default" {}
+
+# Check a recorded webhook delivery, for example in a smoke test module
+check "recorded_webhook" {
+  assert {
+    condition = provider::replicate::verify_webhook_signature(
+      data.replicate_webhook_secret.default.key,
+      "msg_p5jXN8AQM9LWM0D4loKWxJek",
+      "1614265330",
+      file("${path.module}/testdata/webhook.json"),
+      "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+    )
+    error_message = "The recorded webhook's signature doesn't match the account's signing secret."
+  }
+}
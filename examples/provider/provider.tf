@@ -1,20 +1,31 @@
 provider "replicate" {
-  # NOTE: This is populated from the `TF_VAR_REPLICATE_API_TOKEN` environment variable.
+  # NOTE: If omitted, `api_token` is read from the `REPLICATE_API_TOKEN` environment variable.
   api_token = var.replicate_api_token
+
+  # Throttle requests from every resource and data source together
+  requests_per_second = 5
+  burst               = 10
+
+  # Retry rate limited and failed requests, which large applies can run into
+  retry {
+    max_attempts           = 8
+    min_backoff            = "1s"
+    max_backoff            = "1m"
+    retryable_status_codes = [429, 502, 503]
+  }
 }
 
-# # Data source to get the latest AMI ID
-# data "replicate_model" "stability-ai/sdxl" {
-#   most_recent = true
-#   owners      = ["self"]
-#   filter {
-#     name   = "name"
-#     values = ["my-ami-*"]
-#   }
-# }
+# Look up the most recent version of a model
+data "replicate_model_version" "sdxl" {
+  model       = "stability-ai/sdxl"
+  most_recent = true
+}
 
-# # Use the data source to create an EC2 instance
-# resource "aws_instance" "example" {
-#   ami           = data.aws_ami.latest.id
-#   instance_type = "t2.micro"
-# }
+resource "replicate_deployment" "sdxl" {
+  name          = "sdxl"
+  model         = data.replicate_model_version.sdxl.model
+  version       = data.replicate_model_version.sdxl.version.id
+  hardware      = "gpu-a40-large"
+  min_instances = 0
+  max_instances = 1
+}
@@ -0,0 +1,8 @@
+# Deployments can be imported using their {owner}/{name} identifier.
+terraform import replicate_deployment.terraform-example replicate-testing/terraform-example
+
+# To import every deployment on the account, render import blocks from the
+# replicate_deployments data source (see its deployment_import_blocks example), then let
+# Terraform write the matching resource configuration:
+terraform output -raw deployment_import_blocks > imports.tf
+terraform plan -generate-config-out=deployments.tf
@@ -5,4 +5,13 @@ resource "replicate_deployment" "terraform-example" {
   hardware      = "cpu"
   min_instances = 1
   max_instances = 2
+
+  timeouts {
+    create = "30m"
+    update = "30m"
+  }
+}
+
+output "deployment_release" {
+  value = replicate_deployment.terraform-example.current_release.number
 }
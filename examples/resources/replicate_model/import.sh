@@ -0,0 +1,2 @@
+# Models can be imported using their {owner}/{name} identifier.
+terraform import replicate_model.terraform-example replicate-testing/terraform-example
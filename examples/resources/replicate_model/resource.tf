@@ -0,0 +1,7 @@
+resource "replicate_model" "terraform-example" {
+  owner       = "replicate-testing"
+  name        = "terraform-example"
+  visibility  = "private"
+  hardware    = "cpu"
+  description = "A model managed by Terraform"
+}
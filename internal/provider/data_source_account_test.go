@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccountDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAccountDataSourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_account.current", "id", "replicate-testing"),
+					resource.TestCheckResourceAttr("data.replicate_account.current", "username", "replicate-testing"),
+					resource.TestCheckResourceAttr("data.replicate_account.current", "name", "Replicate Testing"),
+					resource.TestCheckResourceAttr("data.replicate_account.current", "type", "organization"),
+					resource.TestCheckResourceAttr("data.replicate_account.current", "github_url", "https://github.com/replicate"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountDataSourceConfig(api *fakeReplicateAPI) string {
+	return testAccProviderConfig(api) + `
+data "replicate_account" "current" {}
+`
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicate/replicate-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeploymentDataSource{}
+
+func NewDeploymentDataSource() datasource.DataSource {
+	return &DeploymentDataSource{}
+}
+
+// DeploymentDataSource defines the data source implementation.
+type DeploymentDataSource struct {
+	client *replicate.Client
+}
+
+// DeploymentDataSourceModel describes the data source data model.
+type DeploymentDataSourceModel struct {
+	Deployment     types.String            `tfsdk:"deployment"`
+	Owner          types.String            `tfsdk:"owner"`
+	Name           types.String            `tfsdk:"name"`
+	Model          types.String            `tfsdk:"model"`
+	Version        types.String            `tfsdk:"version"`
+	Hardware       types.String            `tfsdk:"hardware"`
+	MinInstances   types.Int64             `tfsdk:"min_instances"`
+	MaxInstances   types.Int64             `tfsdk:"max_instances"`
+	CurrentRelease *DeploymentReleaseModel `tfsdk:"current_release"`
+	Id             types.String            `tfsdk:"id"`
+}
+
+func (d *DeploymentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (d *DeploymentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a Replicate deployment, including deployments managed by other Terraform configurations",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment": schema.StringAttribute{
+				MarkdownDescription: "Deployment identifier ({owner}/{name})",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+/[^/]+$`),
+						"must match the format {owner}/{name}",
+					),
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Owner of the deployment",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the deployment",
+				Computed:            true,
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Model identifier ({model_owner}/{model_name})",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Model version ID",
+				Computed:            true,
+			},
+			"hardware": schema.StringAttribute{
+				MarkdownDescription: "Hardware SKU for the deployment",
+				Computed:            true,
+			},
+			"min_instances": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of instances",
+				Computed:            true,
+			},
+			"max_instances": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of instances",
+				Computed:            true,
+			},
+			"current_release": schema.SingleNestedAttribute{
+				MarkdownDescription: "The deployment's current release. The API only returns the current release, so earlier releases can't be read.",
+				Computed:            true,
+				Attributes:          dataSourceAttributes(currentReleaseAttributes()),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Deployment identifier ({owner}/{name})",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeploymentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ReplicateProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DeploymentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeploymentDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parts := strings.Split(data.Deployment.ValueString(), "/")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Invalid deployment identifier", fmt.Sprintf("Expected {owner}/{name}, got: %s", data.Deployment.ValueString()))
+		return
+	}
+
+	// Make API call to Replicate to get the deployment
+	deployment, err := d.client.GetDeployment(ctx, parts[0], parts[1])
+	if isNotFound(err) {
+		// Unlike the resource, there's nothing to recreate, so this is an error
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deployment"),
+			"Deployment Not Found",
+			fmt.Sprintf("Deployment %s was not found. Check that it exists and is visible to the API token's account.", data.Deployment.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment, got error: %s", err))
+		return
+	}
+
+	// Map the API response to our data model
+	deploymentModel := newDeploymentModel(*deployment)
+	data.Owner = deploymentModel.Owner
+	data.Name = deploymentModel.Name
+	data.Model = deploymentModel.Model
+	data.Version = deploymentModel.Version
+	data.Hardware = deploymentModel.Hardware
+	data.MinInstances = deploymentModel.MinInstances
+	data.MaxInstances = deploymentModel.MaxInstances
+	data.Id = deploymentModel.Id
+	release := newDeploymentReleaseModel(deployment.CurrentRelease)
+	data.CurrentRelease = &release
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "read deployment data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dataSourceAttributes converts computed resource attributes into their
+// data source equivalents, so that both schemas share one definition.
+// Only the attribute types used by shared definitions are supported.
+func dataSourceAttributes(attributes map[string]resourceschema.Attribute) map[string]schema.Attribute {
+	converted := make(map[string]schema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		switch a := attribute.(type) {
+		case resourceschema.StringAttribute:
+			converted[name] = schema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case resourceschema.Int64Attribute:
+			converted[name] = schema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case resourceschema.SingleNestedAttribute:
+			converted[name] = schema.SingleNestedAttribute{
+				MarkdownDescription: a.MarkdownDescription,
+				Computed:            true,
+				Attributes:          dataSourceAttributes(a.Attributes),
+			}
+		default:
+			panic(fmt.Sprintf("unsupported attribute type %T for %s", attribute, name))
+		}
+	}
+	return converted
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicate/replicate-go"
+)
+
+func TestAccDeploymentDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	testCreateDeployments(t, api, []replicate.CreateDeploymentOptions{
+		{Name: "shared", Model: "replicate/hello-world", Version: "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", Hardware: "gpu-t4", MinInstances: 1, MaxInstances: 2},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDeploymentDataSourceConfig(api, "replicate-testing/shared"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "id", "replicate-testing/shared"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "owner", "replicate-testing"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "name", "shared"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "model", "replicate/hello-world"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "version", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "hardware", "gpu-t4"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "min_instances", "1"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "max_instances", "2"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "current_release.number", "1"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "current_release.created_by", "replicate-testing"),
+					resource.TestCheckResourceAttrSet("data.replicate_deployment.test", "current_release.created_at"),
+					resource.TestCheckResourceAttr("data.replicate_deployment.test", "current_release.configuration.hardware", "gpu-t4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentDataSource_NotFound(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentDataSourceConfig(api, "replicate-testing/missing"),
+				ExpectError: regexp.MustCompile(`Deployment Not Found`),
+			},
+			{
+				Config:      testAccDeploymentDataSourceConfig(api, "missing"),
+				ExpectError: regexp.MustCompile(`must match the format`),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentDataSource_Error(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/deployments/replicate-testing/shared", Status: http.StatusForbidden})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentDataSourceConfig(api, "replicate-testing/shared"),
+				ExpectError: regexp.MustCompile(`Unable to read deployment`),
+			},
+		},
+	})
+}
+
+func TestDataSourceAttributes(t *testing.T) {
+	attributes := dataSourceAttributes(currentReleaseAttributes())
+
+	configuration, ok := attributes["configuration"].(schema.SingleNestedAttribute)
+	if !ok || !configuration.Computed {
+		t.Fatalf("expected a computed nested configuration, got %#v", attributes["configuration"])
+	}
+	hardware, ok := configuration.Attributes["hardware"].(schema.StringAttribute)
+	if !ok || !hardware.Computed || hardware.MarkdownDescription != "Hardware SKU" {
+		t.Errorf("expected a computed hardware string with its description, got %#v", configuration.Attributes["hardware"])
+	}
+	if number, ok := attributes["number"].(schema.Int64Attribute); !ok || !number.Computed {
+		t.Errorf("expected a computed number, got %#v", attributes["number"])
+	}
+}
+
+func testAccDeploymentDataSourceConfig(api *fakeReplicateAPI, deployment string) string {
+	return testAccProviderConfig(api) + fmt.Sprintf(`
+data "replicate_deployment" "test" {
+  deployment = %[1]q
+}
+`, deployment)
+}
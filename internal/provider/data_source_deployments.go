@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicate/replicate-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DeploymentsDataSource{}
+
+func NewDeploymentsDataSource() datasource.DataSource {
+	return &DeploymentsDataSource{}
+}
+
+// DeploymentsDataSource defines the data source implementation.
+type DeploymentsDataSource struct {
+	client *replicate.Client
+}
+
+// DeploymentsDataSourceModel describes the data source data model.
+type DeploymentsDataSourceModel struct {
+	Model       types.String      `tfsdk:"model"`
+	Hardware    types.String      `tfsdk:"hardware"`
+	Deployments []DeploymentModel `tfsdk:"deployments"`
+	Id          types.String      `tfsdk:"id"`
+}
+
+func (d *DeploymentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployments"
+}
+
+func (d *DeploymentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the deployments of the account that owns the API token, including deployments created outside Terraform",
+
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Only include deployments of this model ({model_owner}/{model_name})",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+/[^/]+$`),
+						"must match the format {model_owner}/{model_name}",
+					),
+				},
+			},
+			"hardware": schema.StringAttribute{
+				MarkdownDescription: "Only include deployments running on this hardware SKU",
+				Optional:            true,
+			},
+			"deployments": schema.ListNestedAttribute{
+				MarkdownDescription: "List of deployments matching the filters, sorted by owner and name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"owner": schema.StringAttribute{
+							MarkdownDescription: "Owner of the deployment",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the deployment",
+							Computed:            true,
+						},
+						"model": schema.StringAttribute{
+							MarkdownDescription: "Model identifier ({model_owner}/{model_name})",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Model version ID",
+							Computed:            true,
+						},
+						"hardware": schema.StringAttribute{
+							MarkdownDescription: "Hardware SKU",
+							Computed:            true,
+						},
+						"min_instances": schema.Int64Attribute{
+							MarkdownDescription: "Minimum number of instances",
+							Computed:            true,
+						},
+						"max_instances": schema.Int64Attribute{
+							MarkdownDescription: "Maximum number of instances",
+							Computed:            true,
+						},
+						"current_release_number": schema.Int64Attribute{
+							MarkdownDescription: "Number of the deployment's current release",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Deployment identifier ({owner}/{name}), for importing it as a `replicate_deployment` resource",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DeploymentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ReplicateProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DeploymentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DeploymentsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make API call to Replicate to get deployments
+	page, err := d.client.ListDeployments(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployments, got error: %s", err))
+		return
+	}
+	deployments, err := paginate(ctx, d.client, page, 0, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployments, got error: %s", err))
+		return
+	}
+
+	// Select the deployments matching the filters
+	filter := deploymentFilter{
+		model:    data.Model.ValueString(),
+		hardware: data.Hardware.ValueString(),
+	}
+	deployments = filter.apply(deployments)
+
+	// Map the API response to our data model
+	data.Deployments = make([]DeploymentModel, 0, len(deployments))
+	for _, deployment := range deployments {
+		data.Deployments = append(data.Deployments, newDeploymentModel(deployment))
+	}
+
+	// Generate a unique ID for this data source
+	data.Id = types.StringValue("replicate_deployments")
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "read deployments data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deploymentFilter selects deployments by the model and hardware of their
+// current release.
+type deploymentFilter struct {
+	model    string
+	hardware string
+}
+
+// apply returns the deployments matching the filter, sorted by owner and
+// name so that the order doesn't depend on the API response.
+func (f deploymentFilter) apply(deployments []replicate.Deployment) []replicate.Deployment {
+	var matches []replicate.Deployment
+	for _, deployment := range deployments {
+		if f.model != "" && deployment.CurrentRelease.Model != f.model {
+			continue
+		}
+		if f.hardware != "" && deployment.CurrentRelease.Configuration.Hardware != f.hardware {
+			continue
+		}
+		matches = append(matches, deployment)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Owner != matches[j].Owner {
+			return matches[i].Owner < matches[j].Owner
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
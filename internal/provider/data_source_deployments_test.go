@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicate/replicate-go"
+)
+
+func TestAccDeploymentsDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	testCreateDeployments(t, api, []replicate.CreateDeploymentOptions{
+		{Name: "hello-gpu", Model: "replicate/hello-world", Version: "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", Hardware: "gpu-t4", MinInstances: 1, MaxInstances: 2},
+		{Name: "hello-cpu", Model: "replicate/hello-world", Version: "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", Hardware: "cpu", MaxInstances: 1},
+		{Name: "sdxl", Model: "stability-ai/sdxl", Version: fakeVersionID("stability-ai/sdxl:0"), Hardware: "gpu-t4", MaxInstances: 4},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, across more than one page
+			{
+				Config: testAccDeploymentsDataSourceConfig(api, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "id", "replicate_deployments"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.#", "3"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.owner", "replicate-testing"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.name", "hello-cpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.model", "replicate/hello-world"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.version", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.hardware", "cpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.min_instances", "0"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.max_instances", "1"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.current_release_number", "1"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.id", "replicate-testing/hello-cpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.1.name", "hello-gpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.2.name", "sdxl"),
+				),
+			},
+			{
+				Config: testAccDeploymentsDataSourceConfig(api, `model = "replicate/hello-world"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.#", "2"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.name", "hello-cpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.1.name", "hello-gpu"),
+				),
+			},
+			{
+				Config: testAccDeploymentsDataSourceConfig(api, `hardware = "gpu-t4"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.#", "2"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.0.name", "hello-gpu"),
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.1.name", "sdxl"),
+				),
+			},
+			// No matches
+			{
+				Config: testAccDeploymentsDataSourceConfig(api, `
+  model    = "stability-ai/sdxl"
+  hardware = "cpu"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_deployments.test", "deployments.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentsDataSource_InvalidModel(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentsDataSourceConfig(api, `model = "hello-world"`),
+				ExpectError: regexp.MustCompile(`must match the format`),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentsDataSource_Error(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/deployments", Status: http.StatusForbidden})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentsDataSourceConfig(api, ""),
+				ExpectError: regexp.MustCompile(`Unable to read deployments`),
+			},
+		},
+	})
+}
+
+func testAccDeploymentsDataSourceConfig(api *fakeReplicateAPI, filters string) string {
+	return testAccProviderConfig(api) + `
+data "replicate_deployments" "test" {
+  ` + filters + `
+}
+`
+}
+
+// testCreateDeployments creates deployments in the fake API outside Terraform.
+func testCreateDeployments(t *testing.T, api *fakeReplicateAPI, deployments []replicate.CreateDeploymentOptions) {
+	t.Helper()
+
+	client := testPaginationClient(t, api)
+	for _, options := range deployments {
+		if _, err := client.CreateDeployment(context.Background(), options); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeploymentFilter(t *testing.T) {
+	deployment := func(owner, name, model, hardware string) replicate.Deployment {
+		return replicate.Deployment{
+			Owner: owner,
+			Name:  name,
+			CurrentRelease: replicate.DeploymentRelease{
+				Model:         model,
+				Configuration: replicate.DeploymentConfiguration{Hardware: hardware},
+			},
+		}
+	}
+	deployments := []replicate.Deployment{
+		deployment("b", "sdxl", "stability-ai/sdxl", "gpu-a40-large"),
+		deployment("a", "sdxl", "stability-ai/sdxl", "gpu-t4"),
+		deployment("a", "hello", "replicate/hello-world", "cpu"),
+	}
+
+	tests := map[string]struct {
+		filter   deploymentFilter
+		expected []string
+	}{
+		"no filters": {
+			filter:   deploymentFilter{},
+			expected: []string{"a/hello", "a/sdxl", "b/sdxl"},
+		},
+		"model": {
+			filter:   deploymentFilter{model: "stability-ai/sdxl"},
+			expected: []string{"a/sdxl", "b/sdxl"},
+		},
+		"hardware": {
+			filter:   deploymentFilter{hardware: "cpu"},
+			expected: []string{"a/hello"},
+		},
+		"combined": {
+			filter:   deploymentFilter{model: "stability-ai/sdxl", hardware: "gpu-a40-large"},
+			expected: []string{"b/sdxl"},
+		},
+		"no matches": {
+			filter:   deploymentFilter{model: "replicate/hello-world", hardware: "gpu-t4"},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ids []string
+			for _, deployment := range test.filter.apply(deployments) {
+				ids = append(ids, deployment.Owner+"/"+deployment.Name)
+			}
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, ids)
+			}
+		})
+	}
+}
@@ -3,9 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/replicate/replicate-go"
@@ -13,6 +18,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &HardwareDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &HardwareDataSource{}
 
 func NewHardwareDataSource() datasource.DataSource {
 	return &HardwareDataSource{}
@@ -25,8 +31,13 @@ type HardwareDataSource struct {
 
 // HardwareDataSourceModel describes the data source data model.
 type HardwareDataSourceModel struct {
-	Hardware []HardwareModel `tfsdk:"hardware"`
-	Id       types.String    `tfsdk:"id"`
+	SKU       types.String    `tfsdk:"sku"`
+	Name      types.String    `tfsdk:"name"`
+	NameRegex types.String    `tfsdk:"name_regex"`
+	GPUOnly   types.Bool      `tfsdk:"gpu_only"`
+	Hardware  []HardwareModel `tfsdk:"hardware"`
+	Selected  *HardwareModel  `tfsdk:"selected"`
+	Id        types.String    `tfsdk:"id"`
 }
 
 type HardwareModel struct {
@@ -43,22 +54,34 @@ func (d *HardwareDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Retrieves available hardware for Replicate models",
 
 		Attributes: map[string]schema.Attribute{
+			"sku": schema.StringAttribute{
+				MarkdownDescription: "Only include the hardware option with this SKU",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only include hardware options with this exact name",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only include hardware options whose name matches this regular expression",
+				Optional:            true,
+			},
+			"gpu_only": schema.BoolAttribute{
+				MarkdownDescription: "If true, only include GPU hardware options, whose SKUs start with `gpu-`",
+				Optional:            true,
+			},
 			"hardware": schema.ListNestedAttribute{
-				MarkdownDescription: "List of available hardware options",
+				MarkdownDescription: "List of hardware options matching the filters, sorted by SKU",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							MarkdownDescription: "Name of the hardware option",
-							Computed:            true,
-						},
-						"sku": schema.StringAttribute{
-							MarkdownDescription: "SKU of the hardware option",
-							Computed:            true,
-						},
-					},
+					Attributes: hardwareAttributes(),
 				},
 			},
+			"selected": schema.SingleNestedAttribute{
+				MarkdownDescription: "The matching hardware option if exactly one matches the filters, otherwise null",
+				Computed:            true,
+				Attributes:          hardwareAttributes(),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier for this data source",
 				Computed:            true,
@@ -67,24 +90,51 @@ func (d *HardwareDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 	}
 }
 
+// hardwareAttributes returns the attributes of a hardware option.
+func hardwareAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the hardware option",
+			Computed:            true,
+		},
+		"sku": schema.StringAttribute{
+			MarkdownDescription: "SKU of the hardware option",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *HardwareDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data HardwareDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := newHardwareFilter(data)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (d *HardwareDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*replicate.Client)
+	data, ok := req.ProviderData.(*ReplicateProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *replicate.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 func (d *HardwareDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -104,13 +154,24 @@ func (d *HardwareDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	// Select the hardware options matching the filters
+	filter, diags := newHardwareFilter(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	matches := filter.apply(*hardwareOptions)
+
 	// Map the API response to our data model
-	for _, hw := range *hardwareOptions {
+	for _, hw := range matches {
 		data.Hardware = append(data.Hardware, HardwareModel{
 			Name: types.StringValue(hw.Name),
 			SKU:  types.StringValue(hw.SKU),
 		})
 	}
+	if len(data.Hardware) == 1 {
+		data.Selected = &data.Hardware[0]
+	}
 
 	// Generate a unique ID for this data source
 	data.Id = types.StringValue("replicate_hardware")
@@ -121,3 +182,60 @@ func (d *HardwareDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// hardwareFilter selects hardware options by SKU and name.
+type hardwareFilter struct {
+	sku       string
+	name      string
+	nameRegex *regexp.Regexp
+	gpuOnly   bool
+}
+
+// newHardwareFilter parses the filters of the data source.
+// Unknown values are skipped, so they can be validated later.
+func newHardwareFilter(data HardwareDataSourceModel) (hardwareFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filter := hardwareFilter{
+		sku:     data.SKU.ValueString(),
+		name:    data.Name.ValueString(),
+		gpuOnly: data.GPUOnly.ValueBool(),
+	}
+
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+		}
+		filter.nameRegex = re
+	}
+
+	return filter, diags
+}
+
+// apply returns the hardware options matching the filter, sorted by SKU
+// so that the order doesn't depend on the API response.
+func (f hardwareFilter) apply(hardware []replicate.Hardware) []replicate.Hardware {
+	var matches []replicate.Hardware
+	for _, hw := range hardware {
+		if f.sku != "" && hw.SKU != f.sku {
+			continue
+		}
+		if f.name != "" && hw.Name != f.name {
+			continue
+		}
+		if f.nameRegex != nil && !f.nameRegex.MatchString(hw.Name) {
+			continue
+		}
+		if f.gpuOnly && !strings.HasPrefix(hw.SKU, "gpu-") {
+			continue
+		}
+		matches = append(matches, hw)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].SKU < matches[j].SKU
+	})
+
+	return matches
+}
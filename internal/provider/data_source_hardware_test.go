@@ -1,32 +1,194 @@
 package provider
 
 import (
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicate/replicate-go"
 )
 
 func TestAccHardwareDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccHardwareDataSourceConfig(),
+				Config: testAccHardwareDataSourceConfig(api),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.replicate_hardware.test", "id", "replicate_hardware"),
 					resource.TestCheckResourceAttrSet("data.replicate_hardware.test", "hardware.#"),
 					resource.TestCheckResourceAttrSet("data.replicate_hardware.test", "hardware.0.name"),
 					resource.TestCheckResourceAttrSet("data.replicate_hardware.test", "hardware.0.sku"),
+					// Sorted by SKU
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.#", "5"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.0.sku", "cpu"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.1.sku", "gpu-a100-large"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.4.sku", "gpu-t4"),
+					resource.TestCheckNoResourceAttr("data.replicate_hardware.test", "selected"),
+				),
+			},
+			// Lookup by name
+			{
+				Config: testAccHardwareDataSourceFilterConfig(api, `name = "Nvidia T4 GPU"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.#", "1"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "selected.sku", "gpu-t4"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "selected.name", "Nvidia T4 GPU"),
+				),
+			},
+			// Lookup by SKU
+			{
+				Config: testAccHardwareDataSourceFilterConfig(api, `sku = "cpu"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "selected.name", "CPU"),
+				),
+			},
+			// Multiple matches leave selected null
+			{
+				Config: testAccHardwareDataSourceFilterConfig(api, `name_regex = "A40"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.#", "2"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.0.sku", "gpu-a40-large"),
+					resource.TestCheckNoResourceAttr("data.replicate_hardware.test", "selected"),
+				),
+			},
+			{
+				Config: testAccHardwareDataSourceFilterConfig(api, `gpu_only = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.#", "4"),
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.0.sku", "gpu-a100-large"),
+				),
+			},
+			// No matches
+			{
+				Config: testAccHardwareDataSourceFilterConfig(api, `
+  gpu_only = true
+  sku      = "cpu"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.#", "0"),
+					resource.TestCheckNoResourceAttr("data.replicate_hardware.test", "selected"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHardwareDataSource_InvalidRegex(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHardwareDataSourceFilterConfig(api, `name_regex = "("`),
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+		},
+	})
+}
+
+func TestAccHardwareDataSource_Error(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusNotFound})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHardwareDataSourceConfig(api),
+				ExpectError: regexp.MustCompile(`Unable to read hardware options`),
+			},
+		},
+	})
+}
+
+func TestAccHardwareDataSource_Transient(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 1})
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusBadGateway, RetryAfter: "0", Delay: 100 * time.Millisecond, Times: 1})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHardwareDataSourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_hardware.test", "hardware.0.sku", "cpu"),
 				),
 			},
 		},
 	})
 }
 
-func testAccHardwareDataSourceConfig() string {
-	return testAccProviderConfig() + `
+func testAccHardwareDataSourceConfig(api *fakeReplicateAPI) string {
+	return testAccProviderConfig(api) + `
 data "replicate_hardware" "test" {}
 `
 }
+
+func testAccHardwareDataSourceFilterConfig(api *fakeReplicateAPI, filters string) string {
+	return testAccProviderConfig(api) + `
+data "replicate_hardware" "test" {
+  ` + filters + `
+}
+`
+}
+
+func TestHardwareFilter(t *testing.T) {
+	hardware := []replicate.Hardware{
+		{SKU: "gpu-t4", Name: "Nvidia T4 GPU"},
+		{SKU: "cpu", Name: "CPU"},
+		{SKU: "gpu-a40-small", Name: "Nvidia A40 GPU"},
+	}
+
+	tests := map[string]struct {
+		filter   hardwareFilter
+		expected []string
+	}{
+		"no filters": {
+			filter:   hardwareFilter{},
+			expected: []string{"cpu", "gpu-a40-small", "gpu-t4"},
+		},
+		"sku": {
+			filter:   hardwareFilter{sku: "gpu-t4"},
+			expected: []string{"gpu-t4"},
+		},
+		"name": {
+			filter:   hardwareFilter{name: "CPU"},
+			expected: []string{"cpu"},
+		},
+		"name regex": {
+			filter:   hardwareFilter{nameRegex: regexp.MustCompile(`^Nvidia`)},
+			expected: []string{"gpu-a40-small", "gpu-t4"},
+		},
+		"gpu only": {
+			filter:   hardwareFilter{gpuOnly: true},
+			expected: []string{"gpu-a40-small", "gpu-t4"},
+		},
+		"combined": {
+			filter:   hardwareFilter{gpuOnly: true, nameRegex: regexp.MustCompile(`T4`)},
+			expected: []string{"gpu-t4"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for _, hw := range test.filter.apply(hardware) {
+				actual = append(actual, hw.SKU)
+			}
+			if strings.Join(actual, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
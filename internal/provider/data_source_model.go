@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicate/replicate-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelDataSource{}
+
+func NewModelDataSource() datasource.DataSource {
+	return &ModelDataSource{}
+}
+
+// ModelDataSource defines the data source implementation.
+type ModelDataSource struct {
+	client *replicate.Client
+}
+
+// ModelDataSourceModel describes the data source data model.
+type ModelDataSourceModel struct {
+	Model          types.String       `tfsdk:"model"`
+	Owner          types.String       `tfsdk:"owner"`
+	Name           types.String       `tfsdk:"name"`
+	Description    types.String       `tfsdk:"description"`
+	Visibility     types.String       `tfsdk:"visibility"`
+	RunCount       types.Int64        `tfsdk:"run_count"`
+	URL            types.String       `tfsdk:"url"`
+	GithubURL      types.String       `tfsdk:"github_url"`
+	PaperURL       types.String       `tfsdk:"paper_url"`
+	LicenseURL     types.String       `tfsdk:"license_url"`
+	CoverImageURL  types.String       `tfsdk:"cover_image_url"`
+	LatestVersion  *ModelVersionModel `tfsdk:"latest_version"`
+	DefaultExample *ModelExampleModel `tfsdk:"default_example"`
+	Id             types.String       `tfsdk:"id"`
+}
+
+type ModelExampleModel struct {
+	ID        types.String `tfsdk:"id"`
+	Version   types.String `tfsdk:"version"`
+	Status    types.String `tfsdk:"status"`
+	Input     types.String `tfsdk:"input"`
+	Output    types.String `tfsdk:"output"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (d *ModelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (d *ModelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves details of a Replicate model, including its latest version",
+
+		Attributes: map[string]schema.Attribute{
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Model identifier ({model_owner}/{model_name})",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+/[^/]+$`),
+						"must match the format {model_owner}/{model_name}",
+					),
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Owner of the model",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the model",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the model",
+				Computed:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Whether the model is `public` or `private`",
+				Computed:            true,
+			},
+			"run_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of times the model has been run",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model on Replicate",
+				Computed:            true,
+			},
+			"github_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's source code on GitHub",
+				Computed:            true,
+			},
+			"paper_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the paper describing the model",
+				Computed:            true,
+			},
+			"license_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's license",
+				Computed:            true,
+			},
+			"cover_image_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's cover image",
+				Computed:            true,
+			},
+			"latest_version": schema.SingleNestedAttribute{
+				MarkdownDescription: "The most recently pushed version of the model, or null if the model has no versions",
+				Computed:            true,
+				Attributes:          modelVersionAttributes(),
+			},
+			"default_example": schema.SingleNestedAttribute{
+				MarkdownDescription: "The example prediction shown on the model page, or null if there is none",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "The ID of the prediction",
+						Computed:            true,
+					},
+					"version": schema.StringAttribute{
+						MarkdownDescription: "The ID of the model version used for the prediction",
+						Computed:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "The status of the prediction",
+						Computed:            true,
+					},
+					"input": schema.StringAttribute{
+						MarkdownDescription: "JSON-encoded input of the prediction",
+						Computed:            true,
+					},
+					"output": schema.StringAttribute{
+						MarkdownDescription: "JSON-encoded output of the prediction",
+						Computed:            true,
+					},
+					"created_at": schema.StringAttribute{
+						MarkdownDescription: "The creation time of the prediction",
+						Computed:            true,
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ModelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ReplicateProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModelDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make API call to Replicate to get the model
+	components := strings.Split(data.Model.ValueString(), "/")
+	if len(components) != 2 {
+		resp.Diagnostics.AddError("Invalid model identifier", fmt.Sprintf("Expected {model_owner}/{model_name}, got: %s", data.Model.ValueString()))
+		return
+	}
+	model, err := d.client.GetModel(ctx, components[0], components[1])
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		return
+	}
+
+	// Map the API response to our data model
+	data.Owner = types.StringValue(model.Owner)
+	data.Name = types.StringValue(model.Name)
+	data.Description = optionalStringValue(model.Description)
+	data.Visibility = types.StringValue(model.Visibility)
+	data.RunCount = types.Int64Value(int64(model.RunCount))
+	data.URL = types.StringValue(model.URL)
+	data.GithubURL = optionalStringValue(model.GithubURL)
+	data.PaperURL = optionalStringValue(model.PaperURL)
+	data.LicenseURL = optionalStringValue(model.LicenseURL)
+	data.CoverImageURL = optionalStringValue(model.CoverImageURL)
+
+	if model.LatestVersion != nil {
+		latestVersion, diags := newModelVersionModel(*model.LatestVersion)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.LatestVersion = &latestVersion
+	}
+
+	if example := model.DefaultExample; example != nil {
+		input, err := json.Marshal(example.Input)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Response", fmt.Sprintf("Unable to encode default example input, got error: %s", err))
+			return
+		}
+		output, err := json.Marshal(example.Output)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Response", fmt.Sprintf("Unable to encode default example output, got error: %s", err))
+			return
+		}
+		data.DefaultExample = &ModelExampleModel{
+			ID:        types.StringValue(example.ID),
+			Version:   types.StringValue(example.Version),
+			Status:    types.StringValue(example.Status.String()),
+			Input:     types.StringValue(string(input)),
+			Output:    types.StringValue(string(output)),
+			CreatedAt: types.StringValue(example.CreatedAt),
+		}
+	}
+
+	// Generate a unique ID for this data source
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s", model.Owner, model.Name))
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "read model data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccModelDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccModelDataSourceConfig(api, "replicate/hello-world"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model.test", "id", "replicate/hello-world"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "owner", "replicate"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "name", "hello-world"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "visibility", "public"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "description", "The simplest possible model"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "run_count", "42"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "github_url", "https://github.com/replicate/cog-examples"),
+					resource.TestCheckNoResourceAttr("data.replicate_model.test", "paper_url"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.id", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.cog_version", "0.3.0"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.inputs.#", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.inputs.0.name", "text"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.outputs.0.type", "string"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "default_example.status", "succeeded"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "default_example.input", `{"text":"Alice"}`),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "default_example.output", `"hello Alice"`),
+				),
+			},
+			// Models without an example have a null default_example, and missing fields are null
+			{
+				Config: testAccModelDataSourceConfig(api, "stability-ai/sdxl"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.replicate_model.test", "description"),
+					resource.TestCheckResourceAttr("data.replicate_model.test", "latest_version.id", fakeVersionID("stability-ai/sdxl:4")),
+					resource.TestCheckNoResourceAttr("data.replicate_model.test", "default_example"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModelDataSourceConfig(api *fakeReplicateAPI, model string) string {
+	return fmt.Sprintf(testAccProviderConfig(api)+`
+data "replicate_model" "test" {
+  model = %[1]q
+}
+`, model)
+}
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -17,6 +22,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ModelVersionDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ModelVersionDataSource{}
 
 func NewModelVersionDataSource() datasource.DataSource {
 	return &ModelVersionDataSource{}
@@ -29,15 +35,60 @@ type ModelVersionDataSource struct {
 
 // ModelVersionDataSourceModel describes the data source data model.
 type ModelVersionDataSourceModel struct {
-	Model    types.String        `tfsdk:"model"`
-	Versions []ModelVersionModel `tfsdk:"versions"`
-	Id       types.String        `tfsdk:"id"`
+	Model           types.String        `tfsdk:"model"`
+	Limit           types.Int64         `tfsdk:"limit"`
+	MostRecent      types.Bool          `tfsdk:"most_recent"`
+	CogVersionRegex types.String        `tfsdk:"cog_version_regex"`
+	CreatedAfter    types.String        `tfsdk:"created_after"`
+	CreatedBefore   types.String        `tfsdk:"created_before"`
+	Versions        []ModelVersionModel `tfsdk:"versions"`
+	Version         *ModelVersionModel  `tfsdk:"version"`
+	Id              types.String        `tfsdk:"id"`
 }
 
 type ModelVersionModel struct {
-	ID         types.String `tfsdk:"id"`
-	CreatedAt  types.String `tfsdk:"created_at"`
-	CogVersion types.String `tfsdk:"cog_version"`
+	ID            types.String                 `tfsdk:"id"`
+	CreatedAt     types.String                 `tfsdk:"created_at"`
+	CogVersion    types.String                 `tfsdk:"cog_version"`
+	OpenAPISchema types.String                 `tfsdk:"openapi_schema"`
+	Inputs        []ModelVersionParameterModel `tfsdk:"inputs"`
+	Outputs       []ModelVersionParameterModel `tfsdk:"outputs"`
+}
+
+// newModelVersionModel maps a model version returned by the API. If the
+// version's OpenAPI schema can't be parsed, its inputs and outputs are left
+// null with a warning, so one malformed version doesn't fail the read.
+func newModelVersionModel(version replicate.ModelVersion) (ModelVersionModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	data := ModelVersionModel{
+		ID:            types.StringValue(version.ID),
+		CreatedAt:     types.StringValue(version.CreatedAt),
+		CogVersion:    types.StringValue(version.CogVersion),
+		OpenAPISchema: types.StringNull(),
+	}
+
+	if version.OpenAPISchema == nil {
+		return data, diags
+	}
+
+	schema, err := json.Marshal(version.OpenAPISchema)
+	if err != nil {
+		diags.AddError("Invalid Response", fmt.Sprintf("Unable to encode OpenAPI schema of version %s, got error: %s", version.ID, err))
+		return data, diags
+	}
+	data.OpenAPISchema = types.StringValue(string(schema))
+
+	inputs, outputs, err := parseOpenAPISchema(schema)
+	if err != nil {
+		diags.AddWarning(
+			"Invalid OpenAPI Schema",
+			fmt.Sprintf("The inputs and outputs of version %s are null, because its OpenAPI schema couldn't be read: %s", version.ID, err),
+		)
+		return data, diags
+	}
+	data.Inputs, data.Outputs = inputs, outputs
+
+	return data, diags
 }
 
 func (d *ModelVersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -59,26 +110,41 @@ func (d *ModelVersionDataSource) Schema(ctx context.Context, req datasource.Sche
 					),
 				},
 			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of matching versions to return, newest first. Pages of versions are fetched until this many match the filters. Defaults to every matching version. Each version includes its full OpenAPI schema, which can make the state large for models with many versions.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"most_recent": schema.BoolAttribute{
+				MarkdownDescription: "If true, select the most recently created of the matching versions as `version`. Fails if no versions match.",
+				Optional:            true,
+			},
+			"cog_version_regex": schema.StringAttribute{
+				MarkdownDescription: "Only include versions whose Cog version matches this regular expression",
+				Optional:            true,
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: "Only include versions created after this RFC 3339 timestamp",
+				Optional:            true,
+			},
+			"created_before": schema.StringAttribute{
+				MarkdownDescription: "Only include versions created before this RFC 3339 timestamp",
+				Optional:            true,
+			},
 			"versions": schema.ListNestedAttribute{
-				MarkdownDescription: "List of model versions",
+				MarkdownDescription: "List of model versions matching the filters, newest first",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							MarkdownDescription: "The ID of the model version",
-							Computed:            true,
-						},
-						"created_at": schema.StringAttribute{
-							MarkdownDescription: "The creation time of the model version",
-							Computed:            true,
-						},
-						"cog_version": schema.StringAttribute{
-							MarkdownDescription: "The Cog version used for this model version",
-							Computed:            true,
-						},
-					},
+					Attributes: modelVersionAttributes(),
 				},
 			},
+			"version": schema.SingleNestedAttribute{
+				MarkdownDescription: "The selected version: the most recent matching version if `most_recent` is true, otherwise the only matching version. Null if more than one version matches and `most_recent` is not set.",
+				Computed:            true,
+				Attributes:          modelVersionAttributes(),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier for this data source",
 				Computed:            true,
@@ -87,23 +153,113 @@ func (d *ModelVersionDataSource) Schema(ctx context.Context, req datasource.Sche
 	}
 }
 
+// modelVersionAttributes returns the attributes of a model version,
+// shared by every data source that returns model versions.
+func modelVersionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the model version",
+			Computed:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "The creation time of the model version",
+			Computed:            true,
+		},
+		"cog_version": schema.StringAttribute{
+			MarkdownDescription: "The Cog version used for this model version",
+			Computed:            true,
+		},
+		"openapi_schema": schema.StringAttribute{
+			MarkdownDescription: "JSON-encoded OpenAPI schema describing the inputs and outputs of the model version",
+			Computed:            true,
+		},
+		"inputs": schema.ListNestedAttribute{
+			MarkdownDescription: "Inputs accepted by the model version, in display order, taken from the `Input` component of the OpenAPI schema",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: modelVersionParameterAttributes(),
+			},
+		},
+		"outputs": schema.ListNestedAttribute{
+			MarkdownDescription: "Outputs returned by the model version, taken from the `Output` component of the OpenAPI schema. Models returning a single value have one output named `output`.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: modelVersionParameterAttributes(),
+			},
+		},
+	}
+}
+
+// modelVersionParameterAttributes returns the attributes of a model version
+// input or output.
+func modelVersionParameterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the parameter",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "OpenAPI type of the parameter, such as `string`, `integer` or `array`",
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Description of the parameter",
+			Computed:            true,
+		},
+		"default": schema.StringAttribute{
+			MarkdownDescription: "JSON-encoded default value, or null if there is none",
+			Computed:            true,
+		},
+		"required": schema.BoolAttribute{
+			MarkdownDescription: "Whether the parameter is required",
+			Computed:            true,
+		},
+		"minimum": schema.Float64Attribute{
+			MarkdownDescription: "Minimum allowed value of a numeric parameter",
+			Computed:            true,
+		},
+		"maximum": schema.Float64Attribute{
+			MarkdownDescription: "Maximum allowed value of a numeric parameter",
+			Computed:            true,
+		},
+		"enum": schema.ListAttribute{
+			MarkdownDescription: "Allowed values of the parameter. Strings are listed as is and other values as JSON.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (d *ModelVersionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data ModelVersionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := newModelVersionFilter(data)
+	resp.Diagnostics.Append(diags...)
+}
+
 func (d *ModelVersionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*replicate.Client)
+	data, ok := req.ProviderData.(*ReplicateProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *replicate.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 func (d *ModelVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -122,19 +278,52 @@ func (d *ModelVersionDataSource) Read(ctx context.Context, req datasource.ReadRe
 		resp.Diagnostics.AddError("Invalid model identifier", fmt.Sprintf("Expected {model_owner}/{model_name}, got: %s", data.Model.ValueString()))
 		return
 	}
-	versions, err := d.client.ListModelVersions(ctx, components[0], components[1])
+	filter, diags := newModelVersionFilter(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	page, err := d.client.ListModelVersions(ctx, components[0], components[1])
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model versions, got error: %s", err))
+		return
+	}
+	// Filter while paginating, so that the limit counts matching versions
+	versions, err := paginate(ctx, d.client, page, int(data.Limit.ValueInt64()), filter.matches)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model versions, got error: %s", err))
 		return
 	}
 
 	// Map the API response to our data model
-	for _, version := range versions.Results {
-		data.Versions = append(data.Versions, ModelVersionModel{
-			ID:         types.StringValue(version.ID),
-			CreatedAt:  types.StringValue(version.CreatedAt),
-			CogVersion: types.StringValue(version.CogVersion),
-		})
+	for _, version := range versions {
+		model, diags := newModelVersionModel(version)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Versions = append(data.Versions, model)
+	}
+
+	var selected *replicate.ModelVersion
+	switch {
+	case data.MostRecent.ValueBool():
+		if len(versions) == 0 {
+			resp.Diagnostics.AddError("No Matching Versions", fmt.Sprintf("No versions of %s match the given filters, so there is no most recent version to select", data.Model.ValueString()))
+			return
+		}
+		mostRecent := mostRecentModelVersion(versions)
+		selected = &mostRecent
+	case len(versions) == 1:
+		selected = &versions[0]
+	}
+	// Reuse the mapped version, so that schema warnings aren't repeated
+	for i, version := range versions {
+		if selected != nil && version.ID == selected.ID {
+			model := data.Versions[i]
+			data.Version = &model
+			break
+		}
 	}
 
 	// Generate a unique ID for this data source
@@ -146,3 +335,75 @@ func (d *ModelVersionDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// modelVersionFilter selects model versions by Cog version and creation time.
+type modelVersionFilter struct {
+	cogVersion    *regexp.Regexp
+	createdAfter  *time.Time
+	createdBefore *time.Time
+}
+
+// newModelVersionFilter parses the filters of the data source.
+// Unknown values are skipped, so they can be validated later.
+func newModelVersionFilter(data ModelVersionDataSourceModel) (modelVersionFilter, diag.Diagnostics) {
+	var filter modelVersionFilter
+	var diags diag.Diagnostics
+
+	if !data.CogVersionRegex.IsNull() && !data.CogVersionRegex.IsUnknown() {
+		re, err := regexp.Compile(data.CogVersionRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("cog_version_regex"), "Invalid Regular Expression", err.Error())
+		}
+		filter.cogVersion = re
+	}
+
+	parseTime := func(value types.String, attribute string) *time.Time {
+		if value.IsNull() || value.IsUnknown() {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid Timestamp", fmt.Sprintf("Expected an RFC 3339 timestamp like 2024-01-02T15:04:05Z, got: %s", value.ValueString()))
+			return nil
+		}
+		return &t
+	}
+	filter.createdAfter = parseTime(data.CreatedAfter, "created_after")
+	filter.createdBefore = parseTime(data.CreatedBefore, "created_before")
+
+	return filter, diags
+}
+
+// matches reports whether a version matches the filter.
+func (f modelVersionFilter) matches(version replicate.ModelVersion) bool {
+	if f.cogVersion != nil && !f.cogVersion.MatchString(version.CogVersion) {
+		return false
+	}
+	if f.createdAfter != nil || f.createdBefore != nil {
+		createdAt, err := time.Parse(time.RFC3339, version.CreatedAt)
+		if err != nil {
+			return false
+		}
+		if f.createdAfter != nil && !createdAt.After(*f.createdAfter) {
+			return false
+		}
+		if f.createdBefore != nil && !createdAt.Before(*f.createdBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// mostRecentModelVersion returns the most recently created version.
+// Versions with an unparseable creation time are considered oldest.
+func mostRecentModelVersion(versions []replicate.ModelVersion) replicate.ModelVersion {
+	mostRecent := versions[0]
+	mostRecentAt, _ := time.Parse(time.RFC3339, mostRecent.CreatedAt)
+	for _, version := range versions[1:] {
+		createdAt, err := time.Parse(time.RFC3339, version.CreatedAt)
+		if err == nil && createdAt.After(mostRecentAt) {
+			mostRecent, mostRecentAt = version, createdAt
+		}
+	}
+	return mostRecent
+}
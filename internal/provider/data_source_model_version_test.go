@@ -1,19 +1,26 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicate/replicate-go"
 )
 
 func TestAccModelVersionDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
 	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Read testing
 			{
-				Config: testAccModelVersionDataSourceConfig(),
+				Config: testAccModelVersionDataSourceConfig(api),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "model", "stability-ai/sdxl"),
 					resource.TestCheckResourceAttrSet("data.replicate_model_version.sdxl", "id"),
@@ -21,16 +28,243 @@ func TestAccModelVersionDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.replicate_model_version.sdxl", "versions.0.id"),
 					resource.TestCheckResourceAttrSet("data.replicate_model_version.sdxl", "versions.0.created_at"),
 					resource.TestCheckResourceAttrSet("data.replicate_model_version.sdxl", "versions.0.cog_version"),
+					resource.TestCheckResourceAttrSet("data.replicate_model_version.sdxl", "versions.0.openapi_schema"),
+					// Inputs are ordered by x-order
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.#", "3"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.0.name", "prompt"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.0.required", "true"),
+					resource.TestCheckNoResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.0.default"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.1.name", "scheduler"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.1.type", "string"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.1.default", `"K_EULER"`),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.1.enum.#", "2"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.1.enum.1", "K_EULER"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.2.name", "num_outputs"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.2.type", "integer"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.2.default", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.2.minimum", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.inputs.2.maximum", "4"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.outputs.#", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.outputs.0.name", "output"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.outputs.0.type", "array"),
+					// Every page is read
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "5"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.4.id", fakeVersionID("stability-ai/sdxl:0")),
+				),
+			},
+			// Most recent testing
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `most_recent = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "5"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "version.id", fakeVersionID("stability-ai/sdxl:4")),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "version.cog_version", "0.8.4"),
 				),
 			},
+			// Filter testing
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `
+  most_recent       = true
+  cog_version_regex = "^0\\.8\\.[0-2]$"
+  created_after     = "2023-05-15T00:00:00Z"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "version.id", fakeVersionID("stability-ai/sdxl:2")),
+				),
+			},
+			// A single match is selected without most_recent
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `created_before = "2023-06-01T00:00:00Z"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "version.id", fakeVersionID("stability-ai/sdxl:0")),
+				),
+			},
+			// Multiple matches without most_recent leave version null
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `cog_version_regex = "^0\\.8"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "5"),
+					resource.TestCheckNoResourceAttr("data.replicate_model_version.sdxl", "version"),
+				),
+			},
+			// Limit testing
+			{
+				Config: testAccModelVersionDataSourceLimitConfig(api, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "3"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.0.id", fakeVersionID("stability-ai/sdxl:4")),
+				),
+			},
+			// The limit counts matching versions, so matches on later pages are found
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `
+  limit             = 1
+  cog_version_regex = "^0\\.8\\.0$"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.sdxl", "version.id", fakeVersionID("stability-ai/sdxl:0")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccModelVersionDataSource_InvalidFilters(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccModelVersionDataSourceFilterConfig(api, `cog_version_regex = "("`),
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			{
+				Config:      testAccModelVersionDataSourceFilterConfig(api, `created_after = "yesterday"`),
+				ExpectError: regexp.MustCompile(`Invalid Timestamp`),
+			},
+			{
+				Config: testAccModelVersionDataSourceFilterConfig(api, `
+  most_recent    = true
+  created_before = "2020-01-01T00:00:00Z"`),
+				ExpectError: regexp.MustCompile(`No Matching Versions`),
+			},
 		},
 	})
 }
 
-func testAccModelVersionDataSourceConfig() string {
-	return testAccProviderConfig() + `
+func TestAccModelVersionDataSource_InvalidSchema(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.addModel("replicate-testing", "malformed", "public", replicate.ModelVersion{
+		ID:            fakeVersionID("replicate-testing/malformed:0"),
+		CreatedAt:     "2024-01-01T00:00:00.000000Z",
+		CogVersion:    "0.9.0",
+		OpenAPISchema: map[string]interface{}{"components": []interface{}{}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A malformed schema leaves inputs and outputs null instead of failing
+			{
+				Config: testAccProviderConfig(api) + `
+data "replicate_model_version" "malformed" {
+  model = "replicate-testing/malformed"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_model_version.malformed", "versions.#", "1"),
+					resource.TestCheckResourceAttrSet("data.replicate_model_version.malformed", "versions.0.openapi_schema"),
+					resource.TestCheckNoResourceAttr("data.replicate_model_version.malformed", "versions.0.inputs.#"),
+					resource.TestCheckNoResourceAttr("data.replicate_model_version.malformed", "versions.0.outputs.#"),
+					resource.TestCheckResourceAttr("data.replicate_model_version.malformed", "version.id", fakeVersionID("replicate-testing/malformed:0")),
+				),
+			},
+		},
+	})
+}
+
+func TestNewModelVersionModel_InvalidSchema(t *testing.T) {
+	model, diags := newModelVersionModel(replicate.ModelVersion{
+		ID:            "abc",
+		OpenAPISchema: map[string]interface{}{"components": []interface{}{}},
+	})
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if model.OpenAPISchema.IsNull() || model.Inputs != nil || model.Outputs != nil {
+		t.Errorf("expected the schema with null inputs and outputs, got %+v", model)
+	}
+}
+
+func TestModelVersionFilter(t *testing.T) {
+	versions := []replicate.ModelVersion{
+		{ID: "c", CreatedAt: "2024-03-01T00:00:00.000000Z", CogVersion: "0.9.1"},
+		{ID: "b", CreatedAt: "2024-02-01T00:00:00.000000Z", CogVersion: "0.9.0"},
+		{ID: "a", CreatedAt: "2024-01-01T00:00:00.000000Z", CogVersion: "0.8.6"},
+	}
+	after := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		filter   modelVersionFilter
+		expected []string
+	}{
+		"no filters": {
+			filter:   modelVersionFilter{},
+			expected: []string{"c", "b", "a"},
+		},
+		"cog version": {
+			filter:   modelVersionFilter{cogVersion: regexp.MustCompile(`^0\.9\.`)},
+			expected: []string{"c", "b"},
+		},
+		"created after": {
+			filter:   modelVersionFilter{createdAfter: &after},
+			expected: []string{"c", "b"},
+		},
+		"created between": {
+			filter:   modelVersionFilter{createdAfter: &after, createdBefore: &before},
+			expected: []string{"b"},
+		},
+		"no matches": {
+			filter:   modelVersionFilter{cogVersion: regexp.MustCompile(`^1\.`)},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for _, version := range versions {
+				if test.filter.matches(version) {
+					actual = append(actual, version.ID)
+				}
+			}
+			if strings.Join(actual, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMostRecentModelVersion(t *testing.T) {
+	versions := []replicate.ModelVersion{
+		{ID: "a", CreatedAt: "2024-01-01T00:00:00.000000Z"},
+		{ID: "invalid", CreatedAt: "not a time"},
+		{ID: "c", CreatedAt: "2024-03-01T00:00:00.000000Z"},
+		{ID: "b", CreatedAt: "2024-02-01T00:00:00.000000Z"},
+	}
+
+	if actual := mostRecentModelVersion(versions); actual.ID != "c" {
+		t.Errorf("expected c, got %s", actual.ID)
+	}
+}
+
+func testAccModelVersionDataSourceConfig(api *fakeReplicateAPI) string {
+	return testAccProviderConfig(api) + `
+data "replicate_model_version" "sdxl" {
+  model = "stability-ai/sdxl"
+}
+`
+}
+
+func testAccModelVersionDataSourceLimitConfig(api *fakeReplicateAPI, limit int) string {
+	return fmt.Sprintf(testAccProviderConfig(api)+`
+data "replicate_model_version" "sdxl" {
+  model = "stability-ai/sdxl"
+  limit = %[1]d
+}
+`, limit)
+}
+
+func testAccModelVersionDataSourceFilterConfig(api *fakeReplicateAPI, filters string) string {
+	return testAccProviderConfig(api) + `
 data "replicate_model_version" "sdxl" {
   model = "stability-ai/sdxl"
+  ` + filters + `
 }
 `
 }
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicate/replicate-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WebhookSecretDataSource{}
+
+func NewWebhookSecretDataSource() datasource.DataSource {
+	return &WebhookSecretDataSource{}
+}
+
+// WebhookSecretDataSource defines the data source implementation.
+type WebhookSecretDataSource struct {
+	client *replicate.Client
+}
+
+// WebhookSecretDataSourceModel describes the data source data model.
+type WebhookSecretDataSourceModel struct {
+	Key types.String `tfsdk:"key"`
+	Id  types.String `tfsdk:"id"`
+}
+
+func (d *WebhookSecretDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_secret"
+}
+
+func (d *WebhookSecretDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the default signing secret for webhooks sent to the account that owns the configured API token. " +
+			"The secret is stored in Terraform state, so protect the state accordingly.",
+
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Webhook signing key, in the form `whsec_{base64 secret}`",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier for this data source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WebhookSecretDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ReplicateProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *WebhookSecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WebhookSecretDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make API call to Replicate to get the default webhook secret
+	secret, err := d.client.GetDefaultWebhookSecret(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webhook secret, got error: %s", err))
+		return
+	}
+
+	// Map the API response to our data model
+	data.Key = types.StringValue(secret.Key)
+
+	// Generate a unique ID for this data source, without revealing the secret
+	data.Id = types.StringValue("replicate_webhook_secret")
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "read webhook secret data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
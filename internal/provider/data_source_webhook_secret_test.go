@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWebhookSecretDataSource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccWebhookSecretDataSourceConfig(api),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicate_webhook_secret.default", "id", "replicate_webhook_secret"),
+					resource.TestCheckResourceAttr("data.replicate_webhook_secret.default", "key", fakeWebhookSecret),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebhookSecretDataSource_Error(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/webhooks/default/secret", Status: http.StatusForbidden})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWebhookSecretDataSourceConfig(api),
+				ExpectError: regexp.MustCompile(`Unable to read webhook secret`),
+			},
+		},
+	})
+}
+
+func testAccWebhookSecretDataSourceConfig(api *fakeReplicateAPI) string {
+	return testAccProviderConfig(api) + `
+data "replicate_webhook_secret" "default" {}
+`
+}
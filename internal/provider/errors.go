@@ -0,0 +1,14 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/replicate/replicate-go"
+)
+
+// isNotFound reports whether err is a not found response from the Replicate API.
+func isNotFound(err error) bool {
+	var apiErr *replicate.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/replicate/replicate-go"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"not found": {
+			err:      &replicate.APIError{Status: http.StatusNotFound},
+			expected: true,
+		},
+		"wrapped not found": {
+			err:      fmt.Errorf("failed to get deployment: %w", &replicate.APIError{Status: http.StatusNotFound}),
+			expected: true,
+		},
+		"other status": {
+			err:      &replicate.APIError{Status: http.StatusInternalServerError},
+			expected: false,
+		},
+		"other error": {
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+		"nil": {
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := isNotFound(test.err); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,658 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+)
+
+// fakeAPIToken is the API token accepted by fakeReplicateAPI.
+const fakeAPIToken = "r8_fake"
+
+// fakeWebhookSecret is the account's default webhook signing secret.
+const fakeWebhookSecret = "whsec_C2FVsBQIhrscChlQIMV+b5sSYspob7oD"
+
+// fakeAPIPageSize is the number of results the fake returns per page.
+const fakeAPIPageSize = 2
+
+// fakeFault describes an error or delay injected into matching requests.
+type fakeFault struct {
+	// Method to match, or any method if empty.
+	Method string
+	// Path prefix to match, or any path if empty.
+	Path string
+	// Status to respond with, or the normal response if zero.
+	Status int
+	// RetryAfter is sent as the Retry-After header when set.
+	RetryAfter string
+	// Delay before handling the request.
+	Delay time.Duration
+	// Times is the number of requests affected, or every request if zero.
+	Times int
+}
+
+func (f *fakeFault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	return strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// fakeReplicateAPI is an in-memory fake of the Replicate HTTP API,
+// for running acceptance tests without a Replicate account.
+type fakeReplicateAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	account     replicate.Account
+	hardware    []replicate.Hardware
+	models      map[string]*replicate.Model
+	versions    map[string][]replicate.ModelVersion
+	deployments map[string]*replicate.Deployment
+	faults      []*fakeFault
+	requests    []string
+}
+
+// newFakeReplicateAPI starts a fake seeded with the account, hardware and
+// models used by the acceptance tests. It's closed when the test finishes.
+func newFakeReplicateAPI(t *testing.T) *fakeReplicateAPI {
+	t.Helper()
+
+	f := &fakeReplicateAPI{
+		account: replicate.Account{
+			Type:      "organization",
+			Username:  "replicate-testing",
+			Name:      "Replicate Testing",
+			GithubURL: "https://github.com/replicate",
+		},
+		hardware: []replicate.Hardware{
+			{SKU: "cpu", Name: "CPU"},
+			{SKU: "gpu-t4", Name: "Nvidia T4 GPU"},
+			{SKU: "gpu-a40-small", Name: "Nvidia A40 GPU"},
+			{SKU: "gpu-a40-large", Name: "Nvidia A40 (Large) GPU"},
+			{SKU: "gpu-a100-large", Name: "Nvidia A100 (80GB) GPU"},
+		},
+		models:      map[string]*replicate.Model{},
+		versions:    map[string][]replicate.ModelVersion{},
+		deployments: map[string]*replicate.Deployment{},
+	}
+
+	f.addModel("replicate", "hello-world", "public", replicate.ModelVersion{
+		ID:         "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa",
+		CreatedAt:  "2022-04-26T19:29:04.418669Z",
+		CogVersion: "0.3.0",
+		OpenAPISchema: fakeOpenAPISchema(t, `{
+			"Input": {
+				"type": "object",
+				"properties": {
+					"text": {"type": "string", "title": "Text", "x-order": 0, "description": "Text to prefix with 'hello '"}
+				},
+				"required": ["text"]
+			},
+			"Output": {"type": "string", "title": "Output"}
+		}`),
+	})
+	helloWorld := f.models["replicate/hello-world"]
+	helloWorld.Description = "The simplest possible model"
+	helloWorld.GithubURL = "https://github.com/replicate/cog-examples"
+	helloWorld.RunCount = 42
+	helloWorld.DefaultExample = &replicate.Prediction{
+		ID:        "ufawqhfynnddngldkgtslldrkq",
+		Status:    replicate.Succeeded,
+		Model:     "replicate/hello-world",
+		Version:   "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa",
+		Input:     replicate.PredictionInput{"text": "Alice"},
+		Output:    "hello Alice",
+		CreatedAt: "2022-04-26T20:00:00.000000Z",
+	}
+
+	sdxlSchema := fakeOpenAPISchema(t, `{
+		"Input": {
+			"type": "object",
+			"properties": {
+				"prompt": {"type": "string", "title": "Prompt", "x-order": 0, "description": "Input prompt"},
+				"num_outputs": {"type": "integer", "title": "Num Outputs", "x-order": 2, "default": 1, "minimum": 1, "maximum": 4, "description": "Number of images to output"},
+				"scheduler": {"allOf": [{"$ref": "#/components/schemas/scheduler"}], "x-order": 1, "default": "K_EULER", "description": "Scheduler"}
+			},
+			"required": ["prompt"]
+		},
+		"scheduler": {"type": "string", "title": "scheduler", "enum": ["DDIM", "K_EULER"]},
+		"Output": {"type": "array", "items": {"type": "string", "format": "uri"}, "title": "Output"}
+	}`)
+	sdxl := []replicate.ModelVersion{}
+	for i := 0; i < 5; i++ {
+		sdxl = append(sdxl, replicate.ModelVersion{
+			ID:            fakeVersionID(fmt.Sprintf("stability-ai/sdxl:%d", i)),
+			CreatedAt:     fmt.Sprintf("2023-0%d-01T00:00:00.000000Z", i+5),
+			CogVersion:    fmt.Sprintf("0.8.%d", i),
+			OpenAPISchema: sdxlSchema,
+		})
+	}
+	f.addModel("stability-ai", "sdxl", "public", sdxl...)
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// fakeOpenAPISchema wraps Cog-style component schemas in an OpenAPI document.
+func fakeOpenAPISchema(t *testing.T, components string) interface{} {
+	var schema interface{}
+	if err := json.Unmarshal([]byte(`{"openapi": "3.0.2", "components": {"schemas": `+components+`}}`), &schema); err != nil {
+		t.Fatalf("invalid fake OpenAPI schema: %s", err)
+	}
+	return schema
+}
+
+// fakeVersionID derives a stable, valid version ID from a seed.
+func fakeVersionID(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// addModel adds a model with the given versions, oldest first.
+func (f *fakeReplicateAPI) addModel(owner, name, visibility string, versions ...replicate.ModelVersion) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := owner + "/" + name
+	model := &replicate.Model{
+		URL:        "https://replicate.com/" + key,
+		Owner:      owner,
+		Name:       name,
+		Visibility: visibility,
+	}
+	f.models[key] = model
+	f.versions[key] = versions
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		model.LatestVersion = &latest
+	}
+}
+
+// setModelVersions replaces the versions of an existing model, oldest first.
+func (f *fakeReplicateAPI) setModelVersions(owner, name string, versions ...replicate.ModelVersion) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := owner + "/" + name
+	f.versions[key] = versions
+	f.models[key].LatestVersion = nil
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		f.models[key].LatestVersion = &latest
+	}
+}
+
+// injectFault makes matching requests fail or slow down.
+func (f *fakeReplicateAPI) injectFault(fault fakeFault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.faults = append(f.faults, &fault)
+}
+
+// deleteDeployment removes a deployment, as if it was deleted outside Terraform.
+func (f *fakeReplicateAPI) deleteDeployment(owner, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.deployments, owner+"/"+name)
+}
+
+// requestCount returns how many requests were made with the given method and path.
+func (f *fakeReplicateAPI) requestCount(method, path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, r := range f.requests {
+		if r == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *fakeReplicateAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	fault := f.takeFault(r)
+	f.mu.Unlock()
+
+	if fault != nil {
+		time.Sleep(fault.Delay)
+		if fault.Status != 0 {
+			if fault.RetryAfter != "" {
+				w.Header().Set("Retry-After", fault.RetryAfter)
+			}
+			writeFakeError(w, fault.Status, "Injected fault")
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+fakeAPIToken {
+		writeFakeError(w, http.StatusUnauthorized, "You did not pass a valid authentication token")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/account":
+		writeFakeJSON(w, http.StatusOK, f.account)
+	case r.Method == http.MethodGet && r.URL.Path == "/hardware":
+		writeFakeJSON(w, http.StatusOK, f.hardware)
+	case r.Method == http.MethodGet && r.URL.Path == "/webhooks/default/secret":
+		writeFakeJSON(w, http.StatusOK, map[string]string{"key": fakeWebhookSecret})
+	case segments[0] == "models":
+		f.serveModels(w, r, segments[1:])
+	case segments[0] == "deployments":
+		f.serveDeployments(w, r, segments[1:])
+	default:
+		writeFakeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// takeFault returns the first fault matching the request, if any.
+func (f *fakeReplicateAPI) takeFault(r *http.Request) *fakeFault {
+	for i, fault := range f.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				f.faults = append(f.faults[:i], f.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func (f *fakeReplicateAPI) serveModels(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		if r.Method != http.MethodPost {
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var body struct {
+			Owner string `json:"owner"`
+			Name  string `json:"name"`
+			replicate.CreateModelOptions
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		key := body.Owner + "/" + body.Name
+		if _, ok := f.models[key]; ok {
+			writeFakeError(w, http.StatusConflict, "A model with that name and owner already exists")
+			return
+		}
+		if body.Owner != f.account.Username {
+			writeFakeError(w, http.StatusForbidden, "You do not have permission to create models for "+body.Owner)
+			return
+		}
+		if !f.hasHardware(body.Hardware) {
+			writeFakeError(w, http.StatusBadRequest, "Invalid hardware: "+body.Hardware)
+			return
+		}
+		model := &replicate.Model{
+			URL:        "https://replicate.com/" + key,
+			Owner:      body.Owner,
+			Name:       body.Name,
+			Visibility: body.Visibility,
+		}
+		if body.Description != nil {
+			model.Description = *body.Description
+		}
+		if body.GithubURL != nil {
+			model.GithubURL = *body.GithubURL
+		}
+		if body.PaperURL != nil {
+			model.PaperURL = *body.PaperURL
+		}
+		if body.LicenseURL != nil {
+			model.LicenseURL = *body.LicenseURL
+		}
+		if body.CoverImageURL != nil {
+			model.CoverImageURL = *body.CoverImageURL
+		}
+		f.models[key] = model
+		writeFakeJSON(w, http.StatusCreated, model)
+		return
+	}
+
+	if len(segments) < 2 {
+		writeFakeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	key := segments[0] + "/" + segments[1]
+	model, ok := f.models[key]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Model not found")
+		return
+	}
+
+	switch {
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, model)
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		if len(f.versions[key]) > 0 {
+			writeFakeError(w, http.StatusConflict, "Cannot delete a model that has versions")
+			return
+		}
+		delete(f.models, key)
+		delete(f.versions, key)
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 3 && segments[2] == "versions" && r.Method == http.MethodGet:
+		// Versions are listed newest first
+		versions := make([]replicate.ModelVersion, len(f.versions[key]))
+		for i, v := range f.versions[key] {
+			versions[len(versions)-1-i] = v
+		}
+		writeFakePage(w, r, f.URL, versions)
+	case len(segments) == 4 && segments[2] == "versions" && r.Method == http.MethodGet:
+		for _, v := range f.versions[key] {
+			if v.ID == segments[3] {
+				writeFakeJSON(w, http.StatusOK, v)
+				return
+			}
+		}
+		writeFakeError(w, http.StatusNotFound, "Version not found")
+	default:
+		writeFakeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (f *fakeReplicateAPI) serveDeployments(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			keys := make([]string, 0, len(f.deployments))
+			for key := range f.deployments {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			deployments := make([]replicate.Deployment, 0, len(keys))
+			for _, key := range keys {
+				deployments = append(deployments, *f.deployments[key])
+			}
+			writeFakePage(w, r, f.URL, deployments)
+		case http.MethodPost:
+			var options replicate.CreateDeploymentOptions
+			if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+				writeFakeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			key := f.account.Username + "/" + options.Name
+			if _, ok := f.deployments[key]; ok {
+				writeFakeError(w, http.StatusConflict, "A deployment with that name already exists")
+				return
+			}
+			if detail := f.validateRelease(options.Model, options.Version, options.Hardware); detail != "" {
+				writeFakeError(w, http.StatusBadRequest, detail)
+				return
+			}
+			deployment := &replicate.Deployment{
+				Owner: f.account.Username,
+				Name:  options.Name,
+				CurrentRelease: replicate.DeploymentRelease{
+					Number:    1,
+					Model:     options.Model,
+					Version:   options.Version,
+					CreatedAt: time.Now().UTC().Format(time.RFC3339Nano),
+					CreatedBy: f.account,
+					Configuration: replicate.DeploymentConfiguration{
+						Hardware:     options.Hardware,
+						MinInstances: options.MinInstances,
+						MaxInstances: options.MaxInstances,
+					},
+				},
+			}
+			f.deployments[key] = deployment
+			writeFakeJSON(w, http.StatusCreated, deployment)
+		default:
+			writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	if len(segments) != 2 {
+		writeFakeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	key := segments[0] + "/" + segments[1]
+	deployment, ok := f.deployments[key]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Deployment not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, http.StatusOK, deployment)
+	case http.MethodPatch:
+		var options replicate.UpdateDeploymentOptions
+		if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		release := deployment.CurrentRelease
+		if options.Model != nil {
+			release.Model = *options.Model
+		}
+		if options.Version != nil {
+			release.Version = *options.Version
+		}
+		if options.Hardware != nil {
+			release.Configuration.Hardware = *options.Hardware
+		}
+		if options.MinInstances != nil {
+			release.Configuration.MinInstances = *options.MinInstances
+		}
+		if options.MaxInstances != nil {
+			release.Configuration.MaxInstances = *options.MaxInstances
+		}
+		if detail := f.validateRelease(release.Model, release.Version, release.Configuration.Hardware); detail != "" {
+			writeFakeError(w, http.StatusBadRequest, detail)
+			return
+		}
+		release.Number++
+		release.CreatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+		release.CreatedBy = f.account
+		deployment.CurrentRelease = release
+		writeFakeJSON(w, http.StatusOK, deployment)
+	case http.MethodDelete:
+		delete(f.deployments, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// validateRelease returns why a deployment release is invalid, or an empty string.
+func (f *fakeReplicateAPI) validateRelease(model, version, hardware string) string {
+	versions, ok := f.versions[model]
+	if !ok {
+		return "Model not found: " + model
+	}
+	found := false
+	for _, v := range versions {
+		found = found || v.ID == version
+	}
+	if !found {
+		return fmt.Sprintf("Version %s not found for model %s", version, model)
+	}
+	if !f.hasHardware(hardware) {
+		return "Invalid hardware: " + hardware
+	}
+	return ""
+}
+
+func (f *fakeReplicateAPI) hasHardware(sku string) bool {
+	for _, hw := range f.hardware {
+		if hw.SKU == sku {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFakePage writes one page of results, with absolute
+// next and previous URLs like the real API.
+func writeFakePage[T any](w http.ResponseWriter, r *http.Request, baseURL string, results []T) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	if offset < 0 || offset > len(results) {
+		offset = 0
+	}
+	end := offset + fakeAPIPageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	page := replicate.Page[T]{Results: results[offset:end]}
+	if end < len(results) {
+		next := fmt.Sprintf("%s%s?cursor=%d", baseURL, r.URL.Path, end)
+		page.Next = &next
+	}
+	if offset > 0 {
+		previous := fmt.Sprintf("%s%s?cursor=%d", baseURL, r.URL.Path, offset-fakeAPIPageSize)
+		page.Previous = &previous
+	}
+	writeFakeJSON(w, http.StatusOK, page)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, detail string) {
+	writeFakeJSON(w, status, replicate.APIError{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	})
+}
+
+func TestFakeReplicateAPI(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	ctx := context.Background()
+
+	client, err := replicate.NewClient(
+		replicate.WithToken(fakeAPIToken),
+		replicate.WithBaseURL(api.URL),
+		replicate.WithRetryPolicy(1, &replicate.ConstantBackoff{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deployment, err := client.CreateDeployment(ctx, replicate.CreateDeploymentOptions{
+		Name:         "greeter",
+		Model:        "replicate/hello-world",
+		Version:      "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa",
+		Hardware:     "cpu",
+		MaxInstances: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployment.Owner != "replicate-testing" || deployment.CurrentRelease.Number != 1 {
+		t.Errorf("unexpected deployment: %+v", deployment)
+	}
+
+	hardware := "gpu-t4"
+	deployment, err = client.UpdateDeployment(ctx, "replicate-testing", "greeter", replicate.UpdateDeploymentOptions{Hardware: &hardware})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deployment.CurrentRelease.Number != 2 || deployment.CurrentRelease.Configuration.Hardware != "gpu-t4" {
+		t.Errorf("unexpected release: %+v", deployment.CurrentRelease)
+	}
+
+	invalid := "gpu-t4x"
+	if _, err := client.UpdateDeployment(ctx, "replicate-testing", "greeter", replicate.UpdateDeploymentOptions{Hardware: &invalid}); err == nil {
+		t.Error("expected invalid hardware to be rejected")
+	}
+
+	if err := client.DeleteDeployment(ctx, "replicate-testing", "greeter"); err != nil {
+		t.Fatal(err)
+	}
+
+	var apiErr *replicate.APIError
+	_, err = client.GetDeployment(ctx, "replicate-testing", "greeter")
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	versions, err := client.ListModelVersions(ctx, "stability-ai", "sdxl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions.Results) != fakeAPIPageSize || versions.Next == nil {
+		t.Errorf("expected a partial first page, got %d results", len(versions.Results))
+	}
+	if versions.Results[0].ID != fakeVersionID("stability-ai/sdxl:4") {
+		t.Errorf("expected newest version first, got %s", versions.Results[0].ID)
+	}
+}
+
+func TestFakeReplicateAPI_Faults(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	ctx := context.Background()
+
+	client, err := replicate.NewClient(
+		replicate.WithToken(fakeAPIToken),
+		replicate.WithBaseURL(api.URL),
+		replicate.WithRetryPolicy(3, &replicate.ConstantBackoff{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api.injectFault(fakeFault{Method: http.MethodGet, Path: "/account", Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := client.GetCurrentAccount(ctx); err != nil {
+		t.Fatalf("expected retries to succeed, got %s", err)
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 3 {
+		t.Errorf("expected 3 requests, got %d", count)
+	}
+
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusNotFound})
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListHardware(ctx); err == nil {
+			t.Error("expected persistent fault to fail every request")
+		}
+	}
+
+	api.injectFault(fakeFault{Path: "/models", Delay: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	if _, err := client.GetModel(ctx, "replicate", "hello-world"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected a slow reply, took %s", elapsed)
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VerifyWebhookSignatureFunction{}
+
+func NewVerifyWebhookSignatureFunction() function.Function {
+	return &VerifyWebhookSignatureFunction{}
+}
+
+// VerifyWebhookSignatureFunction defines the function implementation.
+type VerifyWebhookSignatureFunction struct{}
+
+func (f *VerifyWebhookSignatureFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "verify_webhook_signature"
+}
+
+func (f *VerifyWebhookSignatureFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Verifies the signature of a Replicate webhook",
+		MarkdownDescription: "Returns whether `signature` is a valid signature of a webhook with the given ID, timestamp and body, " +
+			"using the same scheme as the Replicate client libraries. Runs entirely offline, without calling the API.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "secret",
+				MarkdownDescription: "Webhook signing key, in the form `whsec_{base64 secret}`, for example from the `replicate_webhook_secret` data source",
+			},
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "Value of the `webhook-id` header",
+			},
+			function.StringParameter{
+				Name:                "timestamp",
+				MarkdownDescription: "Value of the `webhook-timestamp` header",
+			},
+			function.StringParameter{
+				Name:                "body",
+				MarkdownDescription: "Raw request body, exactly as received",
+			},
+			function.StringParameter{
+				Name:                "signature",
+				MarkdownDescription: "Value of the `webhook-signature` header: space-separated `{version},{base64 signature}` entries",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *VerifyWebhookSignatureFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret, id, timestamp, body, signature string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &secret, &id, &timestamp, &body, &signature))
+	if resp.Error != nil {
+		return
+	}
+
+	valid, err := verifyWebhookSignature(secret, id, timestamp, body, signature)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(err.position, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, valid))
+}
+
+// webhookArgumentError reports which argument of verify_webhook_signature is invalid.
+type webhookArgumentError struct {
+	position int64
+	message  string
+}
+
+func (e *webhookArgumentError) Error() string {
+	return e.message
+}
+
+// verifyWebhookSignature checks a webhook signature the same way as
+// replicate.ValidateWebhookRequest: an HMAC-SHA256 of "{id}.{timestamp}.{body}",
+// keyed with the base64-decoded part of the secret after "whsec_". The
+// signature is valid if any of its entries matches.
+//
+// Unlike the client library, errors never include the secret.
+func verifyWebhookSignature(secret, id, timestamp, body, signature string) (bool, *webhookArgumentError) {
+	if id == "" {
+		return false, &webhookArgumentError{1, "id must not be empty"}
+	}
+	if timestamp == "" {
+		return false, &webhookArgumentError{2, "timestamp must not be empty"}
+	}
+	if signature == "" {
+		return false, &webhookArgumentError{4, "signature must not be empty"}
+	}
+
+	keyParts := strings.Split(secret, "_")
+	if len(keyParts) != 2 {
+		return false, &webhookArgumentError{0, "invalid secret format, expected whsec_{base64 secret}"}
+	}
+	key, err := base64.StdEncoding.DecodeString(keyParts[1])
+	if err != nil {
+		return false, &webhookArgumentError{0, "invalid secret format, the part after the underscore is not valid base64"}
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(fmt.Sprintf("%s.%s.%s", id, timestamp, body)))
+	expected := h.Sum(nil)
+
+	for _, entry := range strings.Split(signature, " ") {
+		parts := strings.Split(entry, ",")
+		if len(parts) < 2 {
+			return false, &webhookArgumentError{4, fmt.Sprintf("invalid signature format: %q, expected {version},{base64 signature}", entry)}
+		}
+		actual, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return false, &webhookArgumentError{4, fmt.Sprintf("invalid signature format: %q is not valid base64", parts[1])}
+		}
+		if hmac.Equal(actual, expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
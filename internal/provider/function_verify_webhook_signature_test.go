@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/replicate/replicate-go"
+)
+
+// Example webhook from the Standard Webhooks specification, which Replicate follows.
+const (
+	testWebhookSecret    = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	testWebhookID        = "msg_p5jXN8AQM9LWM0D4loKWxJek"
+	testWebhookTimestamp = "1614265330"
+	testWebhookBody      = `{"test": 2432232314}`
+	testWebhookSignature = "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="
+)
+
+func TestAccVerifyWebhookSignatureFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVerifyWebhookSignatureFunctionConfig(testWebhookSignature),
+				Check:  resource.TestCheckOutput("valid", "true"),
+			},
+			{
+				Config: testAccVerifyWebhookSignatureFunctionConfig("v1,bm90IHRoZSBzaWduYXR1cmU="),
+				Check:  resource.TestCheckOutput("valid", "false"),
+			},
+			{
+				Config:      testAccVerifyWebhookSignatureFunctionConfig("not-a-signature"),
+				ExpectError: regexp.MustCompile(`invalid signature format`),
+			},
+		},
+	})
+}
+
+func testAccVerifyWebhookSignatureFunctionConfig(signature string) string {
+	return fmt.Sprintf(`
+output "valid" {
+  value = provider::replicate::verify_webhook_signature(%q, %q, %q, %q, %q)
+}
+`, testWebhookSecret, testWebhookID, testWebhookTimestamp, testWebhookBody, signature)
+}
+
+func TestVerifyWebhookSignatureFunction_Run(t *testing.T) {
+	tests := map[string]struct {
+		secret    string
+		id        string
+		timestamp string
+		signature string
+		expected  bool
+		err       bool
+		argument  int64
+	}{
+		"valid": {
+			secret:    testWebhookSecret,
+			id:        testWebhookID,
+			timestamp: testWebhookTimestamp,
+			signature: testWebhookSignature,
+			expected:  true,
+		},
+		"invalid": {
+			secret:    fakeWebhookSecret,
+			id:        testWebhookID,
+			timestamp: testWebhookTimestamp,
+			signature: testWebhookSignature,
+			expected:  false,
+		},
+		"invalid secret": {
+			secret:    "MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			id:        testWebhookID,
+			timestamp: testWebhookTimestamp,
+			signature: testWebhookSignature,
+			err:       true,
+			argument:  0,
+		},
+		"empty id": {
+			secret:    testWebhookSecret,
+			id:        "",
+			timestamp: testWebhookTimestamp,
+			signature: testWebhookSignature,
+			err:       true,
+			argument:  1,
+		},
+		"empty timestamp": {
+			secret:    testWebhookSecret,
+			id:        testWebhookID,
+			timestamp: "",
+			signature: testWebhookSignature,
+			err:       true,
+			argument:  2,
+		},
+		"empty signature": {
+			secret:    testWebhookSecret,
+			id:        testWebhookID,
+			timestamp: testWebhookTimestamp,
+			signature: "",
+			err:       true,
+			argument:  4,
+		},
+		"invalid signature": {
+			secret:    testWebhookSecret,
+			id:        testWebhookID,
+			timestamp: testWebhookTimestamp,
+			signature: "v1",
+			err:       true,
+			argument:  4,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(test.secret),
+					types.StringValue(test.id),
+					types.StringValue(test.timestamp),
+					types.StringValue(testWebhookBody),
+					types.StringValue(test.signature),
+				}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+
+			NewVerifyWebhookSignatureFunction().Run(context.Background(), req, &resp)
+
+			if test.err {
+				if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != test.argument {
+					t.Fatalf("expected an error for argument %d, got %v", test.argument, resp.Error)
+				}
+				if strings.Contains(resp.Error.Text, strings.TrimPrefix(test.secret, "whsec_")) {
+					t.Errorf("error reveals the secret: %s", resp.Error.Text)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if !resp.Result.Value().Equal(types.BoolValue(test.expected)) {
+				t.Errorf("expected %t, got %s", test.expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+// TestVerifyWebhookSignature checks that the function agrees with the
+// client library's validation.
+func TestVerifyWebhookSignature(t *testing.T) {
+	sign := func(secret, body string) string {
+		key, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(testWebhookID + "." + testWebhookTimestamp + "." + body))
+		return "v1," + base64.StdEncoding.EncodeToString(h.Sum(nil))
+	}
+
+	tests := map[string]struct {
+		secret    string
+		body      string
+		signature string
+		expected  bool
+		err       bool
+	}{
+		"valid": {
+			secret:    testWebhookSecret,
+			body:      testWebhookBody,
+			signature: testWebhookSignature,
+			expected:  true,
+		},
+		"second entry": {
+			secret:    fakeWebhookSecret,
+			body:      testWebhookBody,
+			signature: testWebhookSignature + " " + sign(fakeWebhookSecret, testWebhookBody),
+			expected:  true,
+		},
+		"wrong secret": {
+			secret:    fakeWebhookSecret,
+			body:      testWebhookBody,
+			signature: testWebhookSignature,
+		},
+		"modified body": {
+			secret:    testWebhookSecret,
+			body:      `{"test": 2432232315}`,
+			signature: testWebhookSignature,
+		},
+		"empty body": {
+			secret:    testWebhookSecret,
+			body:      "",
+			signature: sign(testWebhookSecret, ""),
+			expected:  true,
+		},
+		"secret without prefix": {
+			secret:    "MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			body:      testWebhookBody,
+			signature: testWebhookSignature,
+			err:       true,
+		},
+		"secret not base64": {
+			secret:    "whsec_not base64",
+			body:      testWebhookBody,
+			signature: testWebhookSignature,
+			err:       true,
+		},
+		"signature without version": {
+			secret:    testWebhookSecret,
+			body:      testWebhookBody,
+			signature: "g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+			err:       true,
+		},
+		"signature not base64": {
+			secret:    testWebhookSecret,
+			body:      testWebhookBody,
+			signature: "v1,not base64",
+			err:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			valid, err := verifyWebhookSignature(test.secret, testWebhookID, testWebhookTimestamp, test.body, test.signature)
+			if (err != nil) != test.err || valid != test.expected {
+				t.Errorf("expected %t (error: %t), got %t (error: %v)", test.expected, test.err, valid, err)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "https://example.com/webhook", strings.NewReader(test.body))
+			req.Header.Set("webhook-id", testWebhookID)
+			req.Header.Set("webhook-timestamp", testWebhookTimestamp)
+			req.Header.Set("webhook-signature", test.signature)
+			libraryValid, libraryErr := replicate.ValidateWebhookRequest(req, replicate.WebhookSigningSecret{Key: test.secret})
+			if valid != libraryValid || (err != nil) != (libraryErr != nil) {
+				t.Errorf("client library returned %t (error: %v), got %t (error: %v)", libraryValid, libraryErr, valid, err)
+			}
+		})
+	}
+}
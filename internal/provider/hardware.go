@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/replicate/replicate-go"
+)
+
+// hardwareCache holds the hardware SKUs available to a configured provider,
+// so a plan with many deployments lists hardware once.
+type hardwareCache struct {
+	client *replicate.Client
+
+	mu   sync.Mutex
+	skus []string
+}
+
+func newHardwareCache(client *replicate.Client) *hardwareCache {
+	return &hardwareCache{client: client}
+}
+
+// errNoHardware is returned when the API lists no hardware at all,
+// which means the list is unavailable rather than that no SKU is valid.
+var errNoHardware = errors.New("the API didn't list any available hardware")
+
+// SKUs returns the sorted SKUs of the available hardware.
+// Failures and empty lists aren't cached, so a later call can try again.
+func (c *hardwareCache) SKUs(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.skus != nil {
+		return c.skus, nil
+	}
+
+	hardware, err := c.client.ListHardware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(*hardware) == 0 {
+		return nil, errNoHardware
+	}
+
+	skus := make([]string, 0, len(*hardware))
+	for _, hw := range *hardware {
+		skus = append(skus, hw.SKU)
+	}
+	sort.Strings(skus)
+	c.skus = skus
+
+	return skus, nil
+}
+
+// invalidHardwareDetail explains that hardware isn't one of skus,
+// suggesting the closest SKU.
+func invalidHardwareDetail(hardware string, skus []string) string {
+	return fmt.Sprintf("%q is not an available hardware SKU. Did you mean %q?\n\nAvailable SKUs: %s",
+		hardware, closestString(hardware, skus), strings.Join(skus, ", "))
+}
+
+// closestString returns the candidate with the smallest edit distance to s.
+func closestString(s string, candidates []string) string {
+	closest, closestDistance := "", -1
+	for _, candidate := range candidates {
+		if d := editDistance(s, candidate); closestDistance < 0 || d < closestDistance {
+			closest, closestDistance = candidate, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
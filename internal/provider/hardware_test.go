@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/replicate/replicate-go"
+)
+
+func TestHardwareCache(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	cache := newHardwareCache(testPaginationClient(t, api))
+	ctx := context.Background()
+
+	// Failures aren't cached
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusForbidden, Times: 1})
+	if _, err := cache.SKUs(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for i := 0; i < 2; i++ {
+		skus, err := cache.SKUs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := strings.Join(skus, ","); actual != "cpu,gpu-a100-large,gpu-a40-large,gpu-a40-small,gpu-t4" {
+			t.Errorf("unexpected SKUs: %s", actual)
+		}
+	}
+
+	// The successful result is cached
+	if count := api.requestCount(http.MethodGet, "/hardware"); count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+
+	// Other caches, such as another provider instance's, fetch their own list
+	if _, err := newHardwareCache(testPaginationClient(t, api)).SKUs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if count := api.requestCount(http.MethodGet, "/hardware"); count != 3 {
+		t.Errorf("expected 3 requests, got %d", count)
+	}
+}
+
+func TestHardwareCache_Empty(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.hardware = []replicate.Hardware{}
+	cache := newHardwareCache(testPaginationClient(t, api))
+	ctx := context.Background()
+
+	// An empty list means the hardware is unavailable, and isn't cached
+	for i := 0; i < 2; i++ {
+		if _, err := cache.SKUs(ctx); !errors.Is(err, errNoHardware) {
+			t.Fatalf("expected errNoHardware, got %v", err)
+		}
+	}
+	if count := api.requestCount(http.MethodGet, "/hardware"); count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+}
+
+func TestInvalidHardwareDetail(t *testing.T) {
+	detail := invalidHardwareDetail("gpu-t4x", []string{"cpu", "gpu-t4"})
+	if !strings.Contains(detail, `Did you mean "gpu-t4"?`) || !strings.Contains(detail, "Available SKUs: cpu, gpu-t4") {
+		t.Errorf("expected a suggestion and the available SKUs, got: %s", detail)
+	}
+}
+
+func TestClosestString(t *testing.T) {
+	skus := []string{"cpu", "gpu-a100-large", "gpu-a40-large", "gpu-a40-small", "gpu-t4"}
+
+	tests := map[string]string{
+		"gpu-t4x":        "gpu-t4",
+		"gpu-T4":         "gpu-t4",
+		"cpus":           "cpu",
+		"gpu-a40-medium": "gpu-a40-large",
+		"gpu-a100-lrge":  "gpu-a100-large",
+	}
+
+	for input, expected := range tests {
+		if actual := closestString(input, skus); actual != expected {
+			t.Errorf("closestString(%q): expected %q, got %q", input, expected, actual)
+		}
+	}
+
+	if actual := closestString("gpu", nil); actual != "" {
+		t.Errorf("expected no suggestion without candidates, got %q", actual)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"cpu", "", 3},
+		{"", "cpu", 3},
+		{"gpu-t4", "gpu-t4", 0},
+		{"gpu-t4x", "gpu-t4", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		if actual := editDistance(test.a, test.b); actual != test.expected {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", test.a, test.b, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// openAPIDocument is the subset of a model version's OpenAPI schema
+// describing its inputs and outputs. Cog puts these in the Input and Output
+// component schemas.
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPISchema struct {
+	Ref         string                   `json:"$ref"`
+	Type        string                   `json:"type"`
+	Format      string                   `json:"format"`
+	Description string                   `json:"description"`
+	Default     json.RawMessage          `json:"default"`
+	Minimum     *float64                 `json:"minimum"`
+	Maximum     *float64                 `json:"maximum"`
+	Enum        []json.RawMessage        `json:"enum"`
+	Items       *openAPISchema           `json:"items"`
+	AllOf       []openAPISchema          `json:"allOf"`
+	Properties  map[string]openAPISchema `json:"properties"`
+	Required    []string                 `json:"required"`
+	Order       *int                     `json:"x-order"`
+}
+
+// ModelVersionParameterModel describes an input or output of a model version.
+type ModelVersionParameterModel struct {
+	Name        types.String   `tfsdk:"name"`
+	Type        types.String   `tfsdk:"type"`
+	Description types.String   `tfsdk:"description"`
+	Default     types.String   `tfsdk:"default"`
+	Required    types.Bool     `tfsdk:"required"`
+	Minimum     types.Float64  `tfsdk:"minimum"`
+	Maximum     types.Float64  `tfsdk:"maximum"`
+	Enum        []types.String `tfsdk:"enum"`
+}
+
+// parseOpenAPISchema returns the inputs and outputs described by a model
+// version's OpenAPI schema. Either is nil if the schema doesn't describe it.
+func parseOpenAPISchema(schema []byte) (inputs, outputs []ModelVersionParameterModel, err error) {
+	var doc openAPIDocument
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse OpenAPI schema: %w", err)
+	}
+	schemas := doc.Components.Schemas
+
+	if input, ok := schemas["Input"]; ok {
+		inputs = openAPIParameters(schemas, input)
+	}
+
+	if output, ok := schemas["Output"]; ok {
+		output = resolveOpenAPISchema(schemas, output)
+		if output.Type == "object" && len(output.Properties) > 0 {
+			outputs = openAPIParameters(schemas, output)
+		} else {
+			// Most models return a single value rather than an object
+			outputs = []ModelVersionParameterModel{
+				newModelVersionParameterModel(schemas, "output", output, true),
+			}
+		}
+	}
+
+	return inputs, outputs, nil
+}
+
+// openAPIParameters returns the properties of an object schema,
+// in the order Cog displays them.
+func openAPIParameters(schemas map[string]openAPISchema, object openAPISchema) []ModelVersionParameterModel {
+	names := make([]string, 0, len(object.Properties))
+	for name := range object.Properties {
+		names = append(names, name)
+	}
+	order := func(name string) int {
+		if o := object.Properties[name].Order; o != nil {
+			return *o
+		}
+		return len(names)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if oi, oj := order(names[i]), order(names[j]); oi != oj {
+			return oi < oj
+		}
+		return names[i] < names[j]
+	})
+
+	required := map[string]bool{}
+	for _, name := range object.Required {
+		required[name] = true
+	}
+
+	parameters := make([]ModelVersionParameterModel, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, newModelVersionParameterModel(schemas, name, object.Properties[name], required[name]))
+	}
+	return parameters
+}
+
+func newModelVersionParameterModel(schemas map[string]openAPISchema, name string, property openAPISchema, required bool) ModelVersionParameterModel {
+	// Choices are defined as a separate component, referenced with allOf,
+	// while the default and description stay on the property itself.
+	resolved := resolveOpenAPISchema(schemas, property)
+
+	parameter := ModelVersionParameterModel{
+		Name:        types.StringValue(name),
+		Type:        types.StringValue(resolved.Type),
+		Description: optionalStringValue(property.Description),
+		Default:     types.StringNull(),
+		Required:    types.BoolValue(required),
+		Minimum:     types.Float64PointerValue(resolved.Minimum),
+		Maximum:     types.Float64PointerValue(resolved.Maximum),
+	}
+	if resolved.Type == "" {
+		parameter.Type = types.StringNull()
+	}
+	// Cog writes "default": null for optional inputs without a default
+	if len(property.Default) > 0 && string(property.Default) != "null" {
+		parameter.Default = types.StringValue(string(property.Default))
+	}
+	for _, value := range resolved.Enum {
+		parameter.Enum = append(parameter.Enum, types.StringValue(openAPIEnumValue(value)))
+	}
+
+	return parameter
+}
+
+// resolveOpenAPISchema follows $ref and single-element allOf indirections,
+// returning the schema that defines the type.
+func resolveOpenAPISchema(schemas map[string]openAPISchema, s openAPISchema) openAPISchema {
+	// Bound the number of indirections, in case of a reference cycle
+	for i := 0; i < 10; i++ {
+		switch {
+		case s.Ref != "":
+			ref, ok := schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+			if !ok {
+				return s
+			}
+			s = ref
+		case s.Type == "" && len(s.AllOf) == 1:
+			s = s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// openAPIEnumValue returns strings as is and other values as JSON,
+// so that string choices can be compared directly in configuration.
+func openAPIEnumValue(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	return string(value)
+}
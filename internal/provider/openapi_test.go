@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseOpenAPISchema(t *testing.T) {
+	schema := []byte(`{
+		"openapi": "3.0.2",
+		"components": {
+			"schemas": {
+				"Input": {
+					"type": "object",
+					"properties": {
+						"seed": {"type": "integer", "title": "Seed", "default": null},
+						"prompt": {"type": "string", "x-order": 0, "description": "Input prompt"},
+						"width": {"allOf": [{"$ref": "#/components/schemas/width"}], "x-order": 1, "default": 1024}
+					},
+					"required": ["prompt"]
+				},
+				"width": {"type": "integer", "enum": [512, 1024], "minimum": 512, "maximum": 1024},
+				"Output": {
+					"type": "object",
+					"properties": {
+						"text": {"type": "string"},
+						"image": {"type": "string", "format": "uri"}
+					}
+				}
+			}
+		}
+	}`)
+
+	inputs, outputs, err := parseOpenAPISchema(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(inputs) != 3 {
+		t.Fatalf("expected 3 inputs, got %d", len(inputs))
+	}
+	// Ordered by x-order, then by name
+	for i, name := range []string{"prompt", "width", "seed"} {
+		if inputs[i].Name.ValueString() != name {
+			t.Errorf("expected input %d to be %s, got %s", i, name, inputs[i].Name.ValueString())
+		}
+	}
+
+	prompt := inputs[0]
+	if !prompt.Required.ValueBool() || prompt.Type.ValueString() != "string" || prompt.Description.ValueString() != "Input prompt" {
+		t.Errorf("unexpected prompt input: %+v", prompt)
+	}
+	if !prompt.Default.IsNull() || !prompt.Minimum.IsNull() || prompt.Enum != nil {
+		t.Errorf("expected prompt input to have no default, minimum or enum: %+v", prompt)
+	}
+
+	width := inputs[1]
+	if width.Required.ValueBool() || width.Type.ValueString() != "integer" || width.Default.ValueString() != "1024" {
+		t.Errorf("unexpected width input: %+v", width)
+	}
+	if !width.Minimum.Equal(types.Float64Value(512)) || !width.Maximum.Equal(types.Float64Value(1024)) {
+		t.Errorf("expected width input to be between 512 and 1024, got %s and %s", width.Minimum, width.Maximum)
+	}
+	if len(width.Enum) != 2 || width.Enum[0].ValueString() != "512" || width.Enum[1].ValueString() != "1024" {
+		t.Errorf("unexpected width choices: %v", width.Enum)
+	}
+
+	seed := inputs[2]
+	if !seed.Default.IsNull() {
+		t.Errorf("expected a null default to be null, got %s", seed.Default)
+	}
+
+	if len(outputs) != 2 || outputs[0].Name.ValueString() != "image" || outputs[1].Name.ValueString() != "text" {
+		t.Errorf("unexpected outputs: %+v", outputs)
+	}
+}
+
+func TestParseOpenAPISchema_SingleOutput(t *testing.T) {
+	schema := []byte(`{
+		"components": {
+			"schemas": {
+				"Output": {"type": "array", "items": {"type": "string", "format": "uri"}}
+			}
+		}
+	}`)
+
+	inputs, outputs, err := parseOpenAPISchema(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if inputs != nil {
+		t.Errorf("expected no inputs, got %+v", inputs)
+	}
+	if len(outputs) != 1 || outputs[0].Name.ValueString() != "output" || outputs[0].Type.ValueString() != "array" {
+		t.Errorf("unexpected outputs: %+v", outputs)
+	}
+}
+
+func TestParseOpenAPISchema_Invalid(t *testing.T) {
+	if _, _, err := parseOpenAPISchema([]byte(`{"components": []}`)); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+}
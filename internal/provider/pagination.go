@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/replicate/replicate-go"
+)
+
+// paginationTransport fixes requests for the absolute next and previous URLs
+// returned by the API. replicate.Paginate joins them onto the base URL,
+// producing URLs like https://api.replicate.com/v1/https://api.replicate.com/v1/models?cursor=...
+type paginationTransport struct {
+	next http.RoundTripper
+}
+
+func (t *paginationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, prefix := range []string{"/https://", "/http://"} {
+		i := strings.Index(req.URL.Path, prefix)
+		if i < 0 {
+			continue
+		}
+
+		target, err := url.Parse(req.URL.Path[i+1:])
+		// Only follow URLs on the API host, so the token isn't sent anywhere else
+		if err != nil || target.Host != req.URL.Host {
+			break
+		}
+		target.RawQuery = req.URL.RawQuery
+
+		req = req.Clone(req.Context())
+		req.URL = target
+		req.Host = target.Host
+		break
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// paginate returns the results of initialPage and every page after it.
+// If keep is not nil, only the results it returns true for are included.
+// If limit is positive, it stops fetching pages once it has that many results.
+func paginate[T any](ctx context.Context, client *replicate.Client, initialPage *replicate.Page[T], limit int, keep func(T) bool) ([]T, error) {
+	// Canceling stops replicate.Paginate from fetching more pages
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resultsChan, errChan := replicate.Paginate(ctx, client, initialPage)
+
+	var all []T
+	var err error
+	full := func() bool { return limit > 0 && len(all) >= limit }
+
+	// Read until both channels are closed, so Paginate's goroutine can exit
+	for resultsChan != nil || errChan != nil {
+		select {
+		case results, ok := <-resultsChan:
+			if !ok {
+				resultsChan = nil
+				continue
+			}
+			if full() {
+				continue
+			}
+			for _, result := range results {
+				if keep == nil || keep(result) {
+					all = append(all, result)
+				}
+			}
+			if full() {
+				cancel()
+			}
+		case e, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			// Errors after reaching the limit come from canceling
+			if !full() {
+				err = e
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if full() {
+		all = all[:limit]
+	}
+
+	return all, nil
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/replicate/replicate-go"
+)
+
+func testPaginationClient(t *testing.T, api *fakeReplicateAPI) *replicate.Client {
+	t.Helper()
+
+	client, err := replicate.NewClient(
+		replicate.WithToken(fakeAPIToken),
+		replicate.WithBaseURL(api.URL),
+		replicate.WithHTTPClient(&http.Client{
+			Transport: &paginationTransport{next: http.DefaultTransport},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestPaginate(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testPaginationClient(t, api)
+	ctx := context.Background()
+
+	skipNewest := func(version replicate.ModelVersion) bool {
+		return version.ID != fakeVersionID("stability-ai/sdxl:4") && version.ID != fakeVersionID("stability-ai/sdxl:3")
+	}
+
+	tests := map[string]struct {
+		limit    int
+		keep     func(replicate.ModelVersion) bool
+		expected []int
+	}{
+		"all pages":          {limit: 0, expected: []int{4, 3, 2, 1, 0}},
+		"limit within page":  {limit: 1, expected: []int{4}},
+		"limit across pages": {limit: 3, expected: []int{4, 3, 2}},
+		"limit above total":  {limit: 10, expected: []int{4, 3, 2, 1, 0}},
+		"keep":               {limit: 0, keep: skipNewest, expected: []int{2, 1, 0}},
+		"limit after keep":   {limit: 1, keep: skipNewest, expected: []int{2}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := client.ListModelVersions(ctx, "stability-ai", "sdxl")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			versions, err := paginate(ctx, client, page, test.limit, test.keep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(versions) != len(test.expected) {
+				t.Fatalf("expected %d versions, got %d", len(test.expected), len(versions))
+			}
+			for i, version := range versions {
+				if expected := fakeVersionID(fmt.Sprintf("stability-ai/sdxl:%d", test.expected[i])); version.ID != expected {
+					t.Errorf("expected version %d to be %s, got %s", i, expected, version.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testPaginationClient(t, api)
+	ctx := context.Background()
+
+	page, err := client.ListModelVersions(ctx, "stability-ai", "sdxl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api.injectFault(fakeFault{Path: "/models/stability-ai/sdxl/versions", Status: http.StatusNotFound})
+	if _, err := paginate(ctx, client, page, 0, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestPaginationTransport_OtherHost(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &paginationTransport{next: http.DefaultTransport}}
+	resp, err := client.Get(server.URL + "/https://example.com/models?cursor=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if requested != "/https://example.com/models" {
+		t.Errorf("expected request to stay on the original host, got path %s", requested)
+	}
+}
@@ -2,12 +2,19 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/replicate/replicate-go"
 )
@@ -18,6 +25,7 @@ var _ provider.ProviderWithFunctions = &ReplicateProvider{}
 
 const (
 	EnvAccApiToken = "REPLICATE_API_TOKEN"
+	EnvBaseURL     = "REPLICATE_BASE_URL"
 )
 
 var (
@@ -32,10 +40,21 @@ type ReplicateProvider struct {
 	version string
 }
 
+// ReplicateProviderData is passed to resources and data sources by Configure.
+// Each configured provider has its own, so caches aren't shared between
+// provider instances.
+type ReplicateProviderData struct {
+	Client   *replicate.Client
+	Hardware *hardwareCache
+}
+
 // ReplicateProviderModel describes the provider data model.
 type ReplicateProviderModel struct {
-	ApiToken types.String `tfsdk:"api_token"`
-	BaseURL  types.String `tfsdk:"base_url"`
+	ApiToken          types.String  `tfsdk:"api_token"`
+	BaseURL           types.String  `tfsdk:"base_url"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+	Retry             *RetryModel   `tfsdk:"retry"`
 }
 
 func (p *ReplicateProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,13 +66,62 @@ func (p *ReplicateProvider) Schema(ctx context.Context, req provider.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
-				MarkdownDescription: "Replicate API token for authentication",
-				Required:            true,
+				MarkdownDescription: "Replicate API token for authentication. Defaults to the `REPLICATE_API_TOKEN` environment variable.",
+				Optional:            true,
 				Sensitive:           true,
 			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "Replicate API base URL",
+				MarkdownDescription: "Replicate API base URL. Defaults to the `REPLICATE_BASE_URL` environment variable, or the public Replicate API if unset.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum average number of API requests per second, shared by every resource and data source. Unlimited if unset.",
 				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.001),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests sent at once before `requests_per_second` applies. Defaults to `requests_per_second`, rounded down, or `1` if that's lower.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("requests_per_second")),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "Retries for API requests that fail with a retryable status code, such as when rate limited. " +
+					"The provider waits for the `Retry-After` header if the API sends one, and otherwise backs off exponentially. " +
+					"A request isn't retried if the wait would outlast the operation's timeout.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of attempts for each request, including the first. Defaults to `5`. Set to `1` to disable retries.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"min_backoff": schema.StringAttribute{
+						MarkdownDescription: "Delay before the first retry, doubled for each retry after it, such as `\"500ms\"`. Defaults to `\"500ms\"`.",
+						Optional:            true,
+					},
+					"max_backoff": schema.StringAttribute{
+						MarkdownDescription: "Maximum delay between retries, such as `\"30s\"`. Defaults to `\"30s\"`. Also limits delays requested with `Retry-After`.",
+						Optional:            true,
+					},
+					"retryable_status_codes": schema.ListAttribute{
+						MarkdownDescription: "HTTP status codes to retry. Defaults to `[429, 500, 502, 503, 504]`. " +
+							"`429` is retried for every request, since the API rejected it without acting on it. " +
+							"Other status codes are only retried for `GET` requests, since a create, update or delete that failed may still have taken effect.",
+						ElementType: types.Int64Type,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+						},
+					},
+				},
 			},
 		},
 	}
@@ -68,21 +136,85 @@ func (p *ReplicateProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	if data.ApiToken.IsNull() {
-		resp.Diagnostics.AddError(
+	// Values that depend on other resources aren't known during plan,
+	// and falling back to the environment would silently use the wrong one.
+	if data.ApiToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token"),
+			"Unknown API Token",
+			"The provider cannot create the Replicate client because the api_token value is not yet known. "+
+				"Either apply the source of the value first, set the value statically in the configuration, "+
+				"or use the "+EnvAccApiToken+" environment variable.",
+		)
+	}
+
+	if data.BaseURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Unknown Base URL",
+			"The provider cannot create the Replicate client because the base_url value is not yet known. "+
+				"Either apply the source of the value first, set the value statically in the configuration, "+
+				"or use the "+EnvBaseURL+" environment variable.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Configuration takes precedence over environment variables
+	apiToken := os.Getenv(EnvAccApiToken)
+	if !data.ApiToken.IsNull() {
+		apiToken = data.ApiToken.ValueString()
+	}
+
+	baseURL := os.Getenv(EnvBaseURL)
+	if !data.BaseURL.IsNull() {
+		baseURL = data.BaseURL.ValueString()
+	}
+
+	if apiToken == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token"),
 			"Missing API Token",
-			"The api_token attribute is required for the Replicate provider",
+			"The Replicate provider requires an API token. "+
+				"Checked the api_token attribute in the provider configuration and the "+EnvAccApiToken+" environment variable, "+
+				"but neither was set to a non-empty value.",
+		)
+		return
+	}
+
+	if data.RequestsPerSecond.IsUnknown() || data.Burst.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Rate Limit",
+			"The provider cannot configure rate limiting because requests_per_second or burst is not yet known. "+
+				"Either apply the source of the value first, or set the value statically in the configuration.",
 		)
 		return
 	}
 
+	var transport http.RoundTripper = &paginationTransport{next: http.DefaultTransport}
+	if !data.RequestsPerSecond.IsNull() {
+		// Throttle retries too, so they don't add to the load that caused them
+		transport = newRateLimitTransport(transport, data.RequestsPerSecond.ValueFloat64(), int(data.Burst.ValueInt64()))
+	}
+
+	transport, diags := newRetryTransport(transport, data.Retry)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	opts := []replicate.ClientOption{
 		replicate.WithUserAgent(UserAgent + "/" + p.version),
-		replicate.WithToken(data.ApiToken.ValueString()),
+		replicate.WithToken(apiToken),
+		replicate.WithHTTPClient(&http.Client{Transport: transport}),
+		// Retries are handled by the transport
+		replicate.WithRetryPolicy(0, &replicate.ConstantBackoff{}),
 	}
 
-	if !data.BaseURL.IsNull() {
-		opts = append(opts, replicate.WithBaseURL(data.BaseURL.ValueString()))
+	if baseURL != "" {
+		opts = append(opts, replicate.WithBaseURL(baseURL))
 	}
 
 	client, err := replicate.NewClient(opts...)
@@ -94,25 +226,37 @@ func (p *ReplicateProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &ReplicateProviderData{
+		Client:   client,
+		Hardware: newHardwareCache(client),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *ReplicateProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewDeploymentResource,
+		NewModelResource,
 	}
 }
 
 func (p *ReplicateProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAccountDataSource,
+		NewDeploymentDataSource,
+		NewDeploymentsDataSource,
 		NewHardwareDataSource,
+		NewModelDataSource,
 		NewModelVersionDataSource,
+		NewWebhookSecretDataSource,
 	}
 }
 
 func (p *ReplicateProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewVerifyWebhookSignatureFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
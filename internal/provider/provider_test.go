@@ -1,12 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -17,17 +25,301 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"replicate": providerserver.NewProtocol6WithError(New("acctest")()),
 }
 
+// testAccLiveAPIEnv is the environment variable that runs acceptance tests
+// against the real Replicate API, authenticated with REPLICATE_API_TOKEN,
+// instead of the fake. Tests that need the fake are skipped.
+const testAccLiveAPIEnv = "REPLICATE_ACC_LIVE_API"
+
+// testAccLiveAPI reports whether acceptance tests run against the real API.
+// It has the signature of resource.TestStep.SkipFunc, to skip steps that
+// depend on the fake's seeded data.
+func testAccLiveAPI() (bool, error) {
+	return os.Getenv(testAccLiveAPIEnv) != "", nil
+}
+
 func testAccPreCheck(t *testing.T) {
-	if os.Getenv(EnvAccApiToken) == "" {
-		t.Fatalf("%s must be set to run acceptance tests", EnvAccApiToken)
+	if live, _ := testAccLiveAPI(); live && os.Getenv(EnvAccApiToken) == "" {
+		t.Fatalf("%s must be set to run acceptance tests against the Replicate API", EnvAccApiToken)
+	}
+}
+
+// testAccPreCheckFakeAPI skips tests that depend on the fake API's seeded
+// data, fault injection or request log when running against the real API.
+func testAccPreCheckFakeAPI(t *testing.T) {
+	if live, _ := testAccLiveAPI(); live {
+		t.Skipf("%s is set, and this test needs the fake API", testAccLiveAPIEnv)
 	}
 }
 
-func testAccProviderConfig() string {
+// testAccProviderConfig configures the provider to use the given fake API,
+// so acceptance tests run without a Replicate account. Against the real API,
+// the provider reads the token from the environment.
+func testAccProviderConfig(api *fakeReplicateAPI) string {
+	if live, _ := testAccLiveAPI(); live {
+		return `
+provider "replicate" {}
+
+	`
+	}
+
 	return fmt.Sprintf(`
 provider "replicate" {
-	api_token = "%s"
+	api_token = %q
+	base_url  = %q
+}
+
+	`, fakeAPIToken, api.URL)
+}
+
+// testProviderConfigure runs Configure against the given attribute values.
+// Attributes that aren't given are null.
+func testProviderConfigure(t *testing.T, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected provider schema to be an object type")
+	}
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attrs),
+		},
+	}
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, req, resp)
+
+	return resp
+}
+
+// testAuthServer records the token of the last request it receives.
+func testAuthServer(t *testing.T, token *string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type":"user","username":"test"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestProviderConfigure_FromEnvironment(t *testing.T) {
+	var token string
+	server := testAuthServer(t, &token)
+
+	t.Setenv(EnvAccApiToken, "r8_env")
+	t.Setenv(EnvBaseURL, server.URL)
+
+	resp := testProviderConfigure(t, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	data, ok := resp.ResourceData.(*ReplicateProviderData)
+	if !ok {
+		t.Fatalf("expected *ReplicateProviderData, got %T", resp.ResourceData)
+	}
+	client := data.Client
+	if _, err := client.GetCurrentAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "r8_env" {
+		t.Errorf("expected token from environment, got %q", token)
+	}
+}
+
+func TestProviderConfigure_ConfigOverridesEnvironment(t *testing.T) {
+	var token string
+	server := testAuthServer(t, &token)
+
+	t.Setenv(EnvAccApiToken, "r8_env")
+	t.Setenv(EnvBaseURL, "http://invalid.example")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "r8_config"),
+		"base_url":  tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	data, ok := resp.DataSourceData.(*ReplicateProviderData)
+	if !ok {
+		t.Fatalf("expected *ReplicateProviderData, got %T", resp.DataSourceData)
+	}
+	client := data.Client
+	if _, err := client.GetCurrentAccount(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "r8_config" {
+		t.Errorf("expected token from configuration, got %q", token)
+	}
+}
+
+func TestProviderConfigure_MissingToken(t *testing.T) {
+	t.Setenv(EnvAccApiToken, "")
+
+	resp := testProviderConfigure(t, nil)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Missing API Token" {
+		t.Errorf("unexpected error summary: %s", summary)
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), EnvAccApiToken) {
+		t.Errorf("expected error detail to mention %s", EnvAccApiToken)
+	}
 }
 
-	`, os.Getenv(EnvAccApiToken))
+func TestProviderConfigure_UnknownToken(t *testing.T) {
+	t.Setenv(EnvAccApiToken, "r8_env")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unknown API Token" {
+		t.Errorf("unexpected error summary: %s", summary)
+	}
+	if resp.ResourceData != nil {
+		t.Errorf("expected no client to be configured")
+	}
+}
+
+// testRetryValue builds a value for the provider's retry block.
+func testRetryValue(t *testing.T, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	attributeTypes := map[string]tftypes.Type{
+		"max_attempts":           tftypes.Number,
+		"min_backoff":            tftypes.String,
+		"max_backoff":            tftypes.String,
+		"retryable_status_codes": tftypes.List{ElementType: tftypes.Number},
+	}
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range attributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: attributeTypes}, attrs)
+}
+
+func TestProviderConfigure_Retry(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusBadGateway})
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, fakeAPIToken),
+		"base_url":  tftypes.NewValue(tftypes.String, api.URL),
+		"retry": testRetryValue(t, map[string]tftypes.Value{
+			"max_attempts": tftypes.NewValue(tftypes.Number, 3),
+			"min_backoff":  tftypes.NewValue(tftypes.String, "1ms"),
+			"max_backoff":  tftypes.NewValue(tftypes.String, "2ms"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	client := resp.ResourceData.(*ReplicateProviderData).Client
+	if _, err := client.GetCurrentAccount(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 3 {
+		t.Errorf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestProviderConfigure_InvalidRetry(t *testing.T) {
+	tests := map[string]struct {
+		retry   map[string]tftypes.Value
+		summary string
+	}{
+		"invalid duration": {
+			retry: map[string]tftypes.Value{
+				"min_backoff": tftypes.NewValue(tftypes.String, "soon"),
+			},
+			summary: "Invalid Duration",
+		},
+		"min above max": {
+			retry: map[string]tftypes.Value{
+				"min_backoff": tftypes.NewValue(tftypes.String, "10s"),
+				"max_backoff": tftypes.NewValue(tftypes.String, "1s"),
+			},
+			summary: "Invalid Backoff",
+		},
+		"unknown": {
+			retry: map[string]tftypes.Value{
+				"max_attempts": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			},
+			summary: "Unknown Retry Configuration",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"api_token": tftypes.NewValue(tftypes.String, fakeAPIToken),
+				"retry":     testRetryValue(t, test.retry),
+			})
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != test.summary {
+				t.Errorf("expected %q, got %q", test.summary, summary)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_RateLimit(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"api_token":           tftypes.NewValue(tftypes.String, fakeAPIToken),
+		"base_url":            tftypes.NewValue(tftypes.String, api.URL),
+		"requests_per_second": tftypes.NewValue(tftypes.Number, 10),
+		"burst":               tftypes.NewValue(tftypes.Number, 1),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	client := resp.ResourceData.(*ReplicateProviderData).Client
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetCurrentAccount(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %s", elapsed)
+	}
 }
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitTransport throttles requests with a token bucket. The provider
+// shares one client between every resource and data source, so Terraform's
+// parallel operations are throttled together.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// newRateLimitTransport returns a transport allowing requestsPerSecond on
+// average, with bursts of up to burst requests. If burst is zero, it allows
+// bursts of one second's worth of requests.
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond float64, burst int) *rateLimitTransport {
+	if burst <= 0 {
+		burst = max(int(requestsPerSecond), 1)
+	}
+	return &rateLimitTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	start := time.Now()
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if waited := time.Since(start); waited > time.Millisecond {
+		tflog.Debug(ctx, "throttled Replicate API request", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"waited": waited.String(),
+		})
+	}
+
+	return t.next.RoundTrip(req)
+}
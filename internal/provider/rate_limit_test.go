@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+)
+
+// testRateLimitClient returns a client throttled by a rateLimitTransport.
+func testRateLimitClient(t *testing.T, api *fakeReplicateAPI, requestsPerSecond float64, burst int) *replicate.Client {
+	t.Helper()
+
+	client, err := replicate.NewClient(
+		replicate.WithToken(fakeAPIToken),
+		replicate.WithBaseURL(api.URL),
+		replicate.WithHTTPClient(&http.Client{
+			Transport: newRateLimitTransport(http.DefaultTransport, requestsPerSecond, burst),
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRateLimitClient(t, api, 20, 2)
+	ctx := context.Background()
+
+	// Concurrent requests share the bucket: two go straight away,
+	// and the other four wait 50ms each.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCurrentAccount(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %s", elapsed)
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 6 {
+		t.Errorf("expected 6 requests, got %d", count)
+	}
+}
+
+func TestRateLimitTransport_Canceled(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRateLimitClient(t, api, 0.01, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetCurrentAccount(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// The next token isn't available for 100 seconds
+	if _, err := client.GetCurrentAccount(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestNewRateLimitTransport_Burst(t *testing.T) {
+	tests := []struct {
+		requestsPerSecond float64
+		burst             int
+		expected          int
+	}{
+		{10, 0, 10},
+		{2.5, 0, 2},
+		{0.5, 0, 1},
+		{10, 3, 3},
+	}
+
+	for _, test := range tests {
+		transport := newRateLimitTransport(http.DefaultTransport, test.requestsPerSecond, test.burst)
+		if actual := transport.limiter.Burst(); actual != test.expected {
+			t.Errorf("newRateLimitTransport(%v, %d): expected burst %d, got %d", test.requestsPerSecond, test.burst, test.expected, actual)
+		}
+	}
+}
@@ -2,23 +2,42 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/replicate/replicate-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DeploymentResource{}
 var _ resource.ResourceWithImportState = &DeploymentResource{}
+var _ resource.ResourceWithModifyPlan = &DeploymentResource{}
+
+// Default timeouts for deployment operations, used unless overridden
+// in the resource's timeouts block.
+const (
+	defaultDeploymentCreateTimeout = 20 * time.Minute
+	defaultDeploymentReadTimeout   = 5 * time.Minute
+	defaultDeploymentUpdateTimeout = 20 * time.Minute
+	defaultDeploymentDeleteTimeout = 5 * time.Minute
+)
 
 func NewDeploymentResource() resource.Resource {
 	return &DeploymentResource{}
@@ -26,19 +45,98 @@ func NewDeploymentResource() resource.Resource {
 
 // DeploymentResource defines the resource implementation.
 type DeploymentResource struct {
-	client *replicate.Client
+	client   *replicate.Client
+	hardware *hardwareCache
 }
 
 // DeploymentResourceModel describes the resource data model.
 type DeploymentResourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	Owner        types.String `tfsdk:"owner"`
-	Model        types.String `tfsdk:"model"`
-	Version      types.String `tfsdk:"version"`
+	Name           types.String   `tfsdk:"name"`
+	Owner          types.String   `tfsdk:"owner"`
+	Model          types.String   `tfsdk:"model"`
+	Version        types.String   `tfsdk:"version"`
+	Hardware       types.String   `tfsdk:"hardware"`
+	MinInstances   types.Int64    `tfsdk:"min_instances"`
+	MaxInstances   types.Int64    `tfsdk:"max_instances"`
+	CurrentRelease types.Object   `tfsdk:"current_release"`
+	Id             types.String   `tfsdk:"id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// DeploymentReleaseModel describes a release of a deployment.
+type DeploymentReleaseModel struct {
+	Number        types.Int64                  `tfsdk:"number"`
+	CreatedAt     types.String                 `tfsdk:"created_at"`
+	CreatedBy     types.String                 `tfsdk:"created_by"`
+	Configuration DeploymentConfigurationModel `tfsdk:"configuration"`
+}
+
+// DeploymentConfigurationModel describes the configuration of a release.
+type DeploymentConfigurationModel struct {
 	Hardware     types.String `tfsdk:"hardware"`
 	MinInstances types.Int64  `tfsdk:"min_instances"`
 	MaxInstances types.Int64  `tfsdk:"max_instances"`
-	Id           types.String `tfsdk:"id"`
+}
+
+var deploymentConfigurationAttrTypes = map[string]attr.Type{
+	"hardware":      types.StringType,
+	"min_instances": types.Int64Type,
+	"max_instances": types.Int64Type,
+}
+
+var deploymentReleaseAttrTypes = map[string]attr.Type{
+	"number":        types.Int64Type,
+	"created_at":    types.StringType,
+	"created_by":    types.StringType,
+	"configuration": types.ObjectType{AttrTypes: deploymentConfigurationAttrTypes},
+}
+
+// newDeploymentReleaseModel maps a release returned by the API.
+func newDeploymentReleaseModel(release replicate.DeploymentRelease) DeploymentReleaseModel {
+	return DeploymentReleaseModel{
+		Number:    types.Int64Value(int64(release.Number)),
+		CreatedAt: types.StringValue(release.CreatedAt),
+		CreatedBy: types.StringValue(release.CreatedBy.Username),
+		Configuration: DeploymentConfigurationModel{
+			Hardware:     types.StringValue(release.Configuration.Hardware),
+			MinInstances: types.Int64Value(int64(release.Configuration.MinInstances)),
+			MaxInstances: types.Int64Value(int64(release.Configuration.MaxInstances)),
+		},
+	}
+}
+
+// newDeploymentReleaseValue returns the current_release value for a release.
+func newDeploymentReleaseValue(ctx context.Context, release replicate.DeploymentRelease) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, deploymentReleaseAttrTypes, newDeploymentReleaseModel(release))
+}
+
+// DeploymentModel describes a deployment in the deployments list, and is
+// the mapping from the API shared by the resource and data sources.
+type DeploymentModel struct {
+	Owner                types.String `tfsdk:"owner"`
+	Name                 types.String `tfsdk:"name"`
+	Model                types.String `tfsdk:"model"`
+	Version              types.String `tfsdk:"version"`
+	Hardware             types.String `tfsdk:"hardware"`
+	MinInstances         types.Int64  `tfsdk:"min_instances"`
+	MaxInstances         types.Int64  `tfsdk:"max_instances"`
+	CurrentReleaseNumber types.Int64  `tfsdk:"current_release_number"`
+	Id                   types.String `tfsdk:"id"`
+}
+
+// newDeploymentModel maps a deployment returned by the API.
+func newDeploymentModel(deployment replicate.Deployment) DeploymentModel {
+	return DeploymentModel{
+		Owner:                types.StringValue(deployment.Owner),
+		Name:                 types.StringValue(deployment.Name),
+		Model:                types.StringValue(deployment.CurrentRelease.Model),
+		Version:              types.StringValue(deployment.CurrentRelease.Version),
+		Hardware:             types.StringValue(deployment.CurrentRelease.Configuration.Hardware),
+		MinInstances:         types.Int64Value(int64(deployment.CurrentRelease.Configuration.MinInstances)),
+		MaxInstances:         types.Int64Value(int64(deployment.CurrentRelease.Configuration.MaxInstances)),
+		CurrentReleaseNumber: types.Int64Value(int64(deployment.CurrentRelease.Number)),
+		Id:                   types.StringValue(fmt.Sprintf("%s/%s", deployment.Owner, deployment.Name)),
+	}
 }
 
 func (r *DeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,16 +146,26 @@ func (r *DeploymentResource) Metadata(ctx context.Context, req resource.Metadata
 func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Deployment resource",
+		MarkdownDescription: "Deployment resource. Create and update time out after 20 minutes, and read and delete after 5 minutes, unless overridden in the `timeouts` block.\n\n" +
+			"The Replicate API doesn't report the status of a deployment's instances, so create and update finish as soon as the API accepts the new release. They can't wait for the instances to be ready.\n\n" +
+			"The API doesn't expose a deployment's release history, so there is no way to roll back to an earlier release by number. To roll back, set `version` and `hardware` back to the earlier release's values, which creates a new release with that configuration.",
 
 		Attributes: map[string]schema.Attribute{
 			"owner": schema.StringAttribute{
-				MarkdownDescription: "Owner of the deployment",
-				Required:            true,
+				MarkdownDescription: "Owner of the deployment. Defaults to the account that owns the API token, which is the only account deployments can be created under.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the deployment",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"model": schema.StringAttribute{
 				MarkdownDescription: "Model identifier ({model_owner}/{model_name})",
@@ -68,16 +176,19 @@ func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequ
 						"must match the format {model_owner}/{model_name}",
 					),
 				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"version": schema.StringAttribute{
-				MarkdownDescription: "Model version ID",
+				MarkdownDescription: "Model version ID. Checked against the versions of `model` when planning.",
 				Required:            true,
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-fA-F0-9]+$`), "must be a valid version ID"),
 				},
 			},
 			"hardware": schema.StringAttribute{
-				MarkdownDescription: "Hardware SKU for the deployment",
+				MarkdownDescription: "Hardware SKU for the deployment. Checked against the available hardware when planning; see the `replicate_hardware` data source.",
 				Required:            true,
 			},
 			"min_instances": schema.Int64Attribute{
@@ -94,13 +205,238 @@ func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequ
 					int64validator.AtLeastSumOf(path.MatchRoot("min_instances")),
 				},
 			},
+			"current_release": schema.SingleNestedAttribute{
+				MarkdownDescription: "The deployment's current release. Every change to `version`, `hardware`, `min_instances` or `max_instances` creates a new release. " +
+					"The API only returns the current release, so earlier releases can't be read.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: currentReleaseAttributes(),
+			},
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// currentReleaseAttributes returns the attributes of a deployment release,
+// shared by the deployment resource and data source.
+func currentReleaseAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"number": schema.Int64Attribute{
+			MarkdownDescription: "Release number, starting at 1 and increasing with each release",
+			Computed:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "When the release was created",
+			Computed:            true,
+		},
+		"created_by": schema.StringAttribute{
+			MarkdownDescription: "Username of the account that created the release",
+			Computed:            true,
+		},
+		"configuration": schema.SingleNestedAttribute{
+			MarkdownDescription: "Configuration of the release",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"hardware": schema.StringAttribute{
+					MarkdownDescription: "Hardware SKU",
+					Computed:            true,
+				},
+				"min_instances": schema.Int64Attribute{
+					MarkdownDescription: "Minimum number of instances",
+					Computed:            true,
+				},
+				"max_instances": schema.Int64Attribute{
+					MarkdownDescription: "Maximum number of instances",
+					Computed:            true,
+				},
 			},
 		},
 	}
 }
 
+func (r *DeploymentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	r.modifyPlanOwner(ctx, req, resp)
+	r.modifyPlanCurrentRelease(ctx, req, resp)
+	r.validatePlanVersion(ctx, req, resp)
+	r.validatePlanHardware(ctx, req, resp)
+}
+
+// modifyPlanCurrentRelease marks current_release as changing when the plan
+// creates a new release, and keeps it otherwise.
+func (r *DeploymentResource) modifyPlanCurrentRelease(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DeploymentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !deploymentReleaseChanged(plan, state) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("current_release"), types.ObjectUnknown(deploymentReleaseAttrTypes))...)
+}
+
+// deploymentReleaseChanged reports whether applying plan over state
+// updates the deployment, which creates a new release.
+func deploymentReleaseChanged(plan, state DeploymentResourceModel) bool {
+	return !plan.Version.Equal(state.Version) || !plan.Hardware.Equal(state.Hardware) ||
+		!plan.MinInstances.Equal(state.MinInstances) || !plan.MaxInstances.Equal(state.MaxInstances)
+}
+
+// modifyPlanOwner defaults the owner to the account that owns the API token,
+// and checks that a configured owner matches it.
+func (r *DeploymentResource) modifyPlanOwner(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configOwner, planOwner, stateOwner types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("owner"), &configOwner)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("owner"), &planOwner)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("owner"), &stateOwner)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The owner of an existing deployment was already checked when it was created,
+	// and an owner that depends on other resources can't be checked until apply.
+	if configOwner.IsUnknown() || (!stateOwner.IsNull() && planOwner.Equal(stateOwner)) {
+		return
+	}
+
+	// CreateDeployment always uses the account that owns the API token
+	account, err := r.client.GetCurrentAccount(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read current account, got error: %s", err))
+		return
+	}
+
+	if configOwner.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("owner"), account.Username)...)
+		return
+	}
+
+	if configOwner.ValueString() != account.Username {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("owner"),
+			"Owner Mismatch",
+			fmt.Sprintf("Deployments are created under the account that owns the API token, %q, but owner is set to %q. "+
+				"Remove owner from the configuration, or use an API token for %q.", account.Username, configOwner.ValueString(), configOwner.ValueString()),
+		)
+	}
+}
+
+// validatePlanVersion checks that the version belongs to the model, since
+// CreateDeployment only reports a mismatch partway through apply.
+func (r *DeploymentResource) validatePlanVersion(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan DeploymentResourceModel
+	var stateModel, stateVersion types.String
+	diags := req.Plan.Get(ctx, &plan)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("model"), &stateModel)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("version"), &stateVersion)...)
+	}
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// Values that depend on other resources can't be checked until apply,
+	// and an unchanged version was already accepted by the API.
+	if plan.Model.IsUnknown() || plan.Version.IsUnknown() || plan.Model.IsNull() || plan.Version.IsNull() {
+		return
+	}
+	if plan.Model.Equal(stateModel) && plan.Version.Equal(stateVersion) {
+		return
+	}
+
+	model := plan.Model.ValueString()
+	parts := strings.Split(model, "/")
+	if len(parts) != 2 {
+		// Reported by the model attribute's validator
+		return
+	}
+
+	_, err := r.client.GetModelVersion(ctx, parts[0], parts[1], plan.Version.ValueString())
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Version Not Found",
+			fmt.Sprintf("Version %q was not found for model %q. Check that the version belongs to this model, and that the model exists and is visible to the API token's account.",
+				plan.Version.ValueString(), model),
+		)
+		return
+	}
+	if err != nil {
+		// The API checks the version again when applying, so don't fail the plan
+		tflog.Warn(ctx, "unable to read model version, skipping version validation", map[string]interface{}{"error": err.Error()})
+		return
+	}
+}
+
+// validatePlanHardware checks that the hardware SKU is available, so that
+// typos are caught before apply starts changing other resources.
+func (r *DeploymentResource) validatePlanHardware(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planHardware, stateHardware types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root("hardware"), &planHardware)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("hardware"), &stateHardware)...)
+	}
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// Hardware that depends on other resources can't be checked until apply,
+	// and unchanged hardware was already accepted by the API.
+	if planHardware.IsUnknown() || planHardware.IsNull() || planHardware.Equal(stateHardware) {
+		return
+	}
+
+	skus, err := r.hardware.SKUs(ctx)
+	if err != nil {
+		// The API checks the hardware again when applying, so don't fail the plan
+		tflog.Warn(ctx, "unable to list hardware, skipping hardware validation", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	hardware := planHardware.ValueString()
+	for _, sku := range skus {
+		if sku == hardware {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("hardware"),
+		"Invalid Hardware SKU",
+		invalidHardwareDetail(hardware, skus),
+	)
+}
+
 func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data DeploymentResourceModel
 
@@ -110,6 +446,14 @@ func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	timeout, diags := data.Timeouts.Create(ctx, defaultDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Create deployment with API
 	deployment, err := r.client.CreateDeployment(ctx, replicate.CreateDeploymentOptions{
 		Name:         data.Name.ValueString(),
@@ -120,19 +464,12 @@ func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		MaxInstances: int(data.MaxInstances.ValueInt64()),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create deployment, got error: %s", err))
+		addDeploymentClientError(ctx, &resp.Diagnostics, "create", timeout, err)
 		return
 	}
 
 	// Update the model with the latest data
-	data.Owner = types.StringValue(deployment.Owner)
-	data.Name = types.StringValue(deployment.Name)
-	data.Model = types.StringValue(deployment.CurrentRelease.Model)
-	data.Version = types.StringValue(deployment.CurrentRelease.Version)
-	data.Hardware = types.StringValue(deployment.CurrentRelease.Configuration.Hardware)
-	data.MinInstances = types.Int64Value(int64(deployment.CurrentRelease.Configuration.MinInstances))
-	data.MaxInstances = types.Int64Value(int64(deployment.CurrentRelease.Configuration.MaxInstances))
-	data.Id = types.StringValue(fmt.Sprintf("%s/%s", deployment.Owner, deployment.Name))
+	resp.Diagnostics.Append(data.setDeployment(ctx, deployment)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -147,41 +484,67 @@ func (r *DeploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	parts := strings.Split(data.Id.ValueString(), "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID in format owner/name, got: %s", data.Id.ValueString()))
+	timeout, diags := data.Timeouts.Read(ctx, defaultDeploymentReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	owner, name, err := parseDeploymentID(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", err.Error())
 		return
 	}
 
 	// Get deployment from API
-	deployment, err := r.client.GetDeployment(ctx, parts[0], parts[1])
+	deployment, err := r.client.GetDeployment(ctx, owner, name)
+	if isNotFound(err) {
+		// The deployment was deleted outside of Terraform, so plan to recreate it
+		tflog.Warn(ctx, "deployment not found, removing from state", map[string]interface{}{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment, got error: %s", err))
+		addDeploymentClientError(ctx, &resp.Diagnostics, "read", timeout, err)
 		return
 	}
 
 	// Update the model with the latest data
-	data.Owner = types.StringValue(deployment.Owner)
-	data.Name = types.StringValue(deployment.Name)
-	data.Model = types.StringValue(deployment.CurrentRelease.Model)
-	data.Version = types.StringValue(deployment.CurrentRelease.Version)
-	data.Hardware = types.StringValue(deployment.CurrentRelease.Configuration.Hardware)
-	data.MinInstances = types.Int64Value(int64(deployment.CurrentRelease.Configuration.MinInstances))
-	data.MaxInstances = types.Int64Value(int64(deployment.CurrentRelease.Configuration.MaxInstances))
-	data.Id = types.StringValue(fmt.Sprintf("%s/%s", deployment.Owner, deployment.Name))
+	resp.Diagnostics.Append(data.setDeployment(ctx, deployment)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data DeploymentResourceModel
+	var data, state DeploymentResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, defaultDeploymentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s", data.Owner.ValueString(), data.Name.ValueString()))
+
+	// Every update creates a new release, so skip the API when only
+	// Terraform-specific settings like timeouts have changed.
+	if !deploymentReleaseChanged(data, state) {
+		data.CurrentRelease = state.CurrentRelease
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
 	// Update deployment with API
 	opts := replicate.UpdateDeploymentOptions{}
@@ -199,12 +562,13 @@ func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequ
 		maxInstances := int(*data.MaxInstances.ValueInt64Pointer())
 		opts.MaxInstances = &maxInstances
 	}
-	_, err := r.client.UpdateDeployment(ctx, data.Owner.ValueString(), data.Name.ValueString(), opts)
+	deployment, err := r.client.UpdateDeployment(ctx, data.Owner.ValueString(), data.Name.ValueString(), opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment, got error: %s", err))
+		addDeploymentClientError(ctx, &resp.Diagnostics, "update", timeout, err)
 		return
 	}
-	data.Id = types.StringValue(fmt.Sprintf("%s/%s", data.Owner.ValueString(), data.Name.ValueString()))
+	data.CurrentRelease, diags = newDeploymentReleaseValue(ctx, deployment.CurrentRelease)
+	resp.Diagnostics.Append(diags...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -219,28 +583,96 @@ func (r *DeploymentResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	timeout, diags := data.Timeouts.Delete(ctx, defaultDeploymentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	err := r.client.DeleteDeployment(ctx, data.Owner.ValueString(), data.Name.ValueString())
+	if isNotFound(err) {
+		// Already deleted, which is the desired outcome
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete deployment, got error: %s", err))
+		addDeploymentClientError(ctx, &resp.Diagnostics, "delete", timeout, err)
 		return
 	}
 }
 
+// setDeployment copies the fields returned by the API into the resource model.
+func (data *DeploymentResourceModel) setDeployment(ctx context.Context, deployment *replicate.Deployment) diag.Diagnostics {
+	deploymentModel := newDeploymentModel(*deployment)
+	data.Owner = deploymentModel.Owner
+	data.Name = deploymentModel.Name
+	data.Model = deploymentModel.Model
+	data.Version = deploymentModel.Version
+	data.Hardware = deploymentModel.Hardware
+	data.MinInstances = deploymentModel.MinInstances
+	data.MaxInstances = deploymentModel.MaxInstances
+	data.Id = deploymentModel.Id
+
+	var diags diag.Diagnostics
+	data.CurrentRelease, diags = newDeploymentReleaseValue(ctx, deployment.CurrentRelease)
+	return diags
+}
+
 func (r *DeploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	owner, name, err := parseDeploymentID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Deployments are imported by {owner}/{name}, for example \"acme/image-generator\", got: %q. "+
+				"The replicate_deployments data source lists the IDs of every deployment on the account.", req.ID),
+		)
+		return
+	}
+
+	// Read fills in the rest, including every required attribute,
+	// so the result can be used with -generate-config-out.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), owner)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// parseDeploymentID splits a deployment ID into its owner and name.
+func parseDeploymentID(id string) (owner, name string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected ID in format owner/name, got: %q", id)
+	}
+	return parts[0], parts[1], nil
 }
 
 func (r *DeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*replicate.Client)
+	data, ok := req.ProviderData.(*ReplicateProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *replicate.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+	r.hardware = data.Hardware
+}
+
+// addDeploymentClientError reports a failed API call. If the operation's
+// timeout ran out, it says so, since the underlying error is usually just
+// "context deadline exceeded".
+func addDeploymentClientError(ctx context.Context, diags *diag.Diagnostics, operation string, timeout time.Duration, err error) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		diags.AddError(
+			"Timeout Exceeded",
+			fmt.Sprintf("Unable to %s deployment within the %s timeout of %s, got error: %s\n\n"+
+				"Increase timeouts.%s to wait longer.", operation, operation, timeout, err, operation),
 		)
 		return
 	}
-	r.client = client
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s deployment, got error: %s", operation, err))
 }
@@ -1,14 +1,27 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/replicate/replicate-go"
 )
 
 func TestAccDeploymentResource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
 	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -17,7 +30,7 @@ func TestAccDeploymentResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccDeploymentResourceConfig("replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("replicate_deployment.test", "owner", "replicate-testing"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "name", rName),
@@ -26,6 +39,12 @@ func TestAccDeploymentResource(t *testing.T) {
 					resource.TestCheckResourceAttr("replicate_deployment.test", "hardware", "cpu"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "min_instances", "0"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "max_instances", "1"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.number", "1"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.created_by", "replicate-testing"),
+					resource.TestCheckResourceAttrSet("replicate_deployment.test", "current_release.created_at"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.configuration.hardware", "cpu"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.configuration.min_instances", "0"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.configuration.max_instances", "1"),
 				),
 			},
 			// ImportState testing
@@ -34,30 +53,71 @@ func TestAccDeploymentResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:  "replicate_deployment.test",
+				ImportState:   true,
+				ImportStateId: rName,
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
 			// Update and Read testing
 			{
-				Config: testAccDeploymentResourceConfig("replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "gpu-t4", 2, 4),
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "gpu-t4", 2, 4),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("replicate_deployment.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("replicate_deployment.test", tfjsonpath.New("id"), knownvalue.StringExact("replicate-testing/"+rName)),
+						plancheck.ExpectUnknownValue("replicate_deployment.test", tfjsonpath.New("current_release")),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("replicate_deployment.test", "hardware", "gpu-t4"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "min_instances", "2"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "max_instances", "4"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.number", "2"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.configuration.hardware", "gpu-t4"),
 				),
 			},
 			{
-				Config: testAccDeploymentResourceConfig("replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 0),
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 0),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("replicate_deployment.test", "hardware", "cpu"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "min_instances", "0"),
 					resource.TestCheckResourceAttr("replicate_deployment.test", "max_instances", "0"),
 				),
 			},
+			// Changing the name replaces the deployment
+			{
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName+"-renamed", "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 0),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("replicate_deployment.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "id", "replicate-testing/"+rName+"-renamed"),
+				),
+			},
+			// Changing the model replaces the deployment
+			{
+				// The fake's model versions don't exist in the real API
+				SkipFunc: testAccLiveAPI,
+				Config:   testAccDeploymentResourceConfig(api, "replicate-testing", rName+"-renamed", "stability-ai/sdxl", fakeVersionID("stability-ai/sdxl:4"), "cpu", 0, 0),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("replicate_deployment.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "model", "stability-ai/sdxl"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
-func testAccDeploymentResourceConfig(owner, name, model, version, hardware string, minInstances, maxInstances int) string {
-	return fmt.Sprintf(testAccProviderConfig()+`
+func testAccDeploymentResourceConfig(api *fakeReplicateAPI, owner, name, model, version, hardware string, minInstances, maxInstances int) string {
+	return fmt.Sprintf(testAccProviderConfig(api)+`
 resource "replicate_deployment" "test" {
   owner         = %[1]q
   name          = %[2]q
@@ -69,3 +129,342 @@ resource "replicate_deployment" "test" {
 }
 `, owner, name, model, version, hardware, minInstances, maxInstances)
 }
+
+func TestAccDeploymentResource_DeletedOutsideTerraform(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	config := testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Refresh removes the deployment from state, and plan recreates it
+			{
+				PreConfig:          func() { api.deleteDeployment("replicate-testing", rName) },
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Apply recreates the deployment
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "id", "replicate-testing/"+rName),
+				),
+			},
+			// Removing the resource succeeds even if the deployment is already gone
+			{
+				PreConfig: func() { api.deleteDeployment("replicate-testing", rName) },
+				Config:    testAccProviderConfig(api),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_Owner(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A configured owner must match the API token's account
+			{
+				Config:      testAccDeploymentResourceConfig(api, "someone-else", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Owner Mismatch`),
+			},
+			// The owner defaults to the API token's account
+			{
+				Config: fmt.Sprintf(testAccProviderConfig(api)+`
+resource "replicate_deployment" "test" {
+  name          = %[1]q
+  model         = "replicate/hello-world"
+  version       = "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"
+  hardware      = "cpu"
+  min_instances = 0
+  max_instances = 1
+}
+`, rName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("replicate_deployment.test", tfjsonpath.New("owner"), knownvalue.StringExact("replicate-testing")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "owner", "replicate-testing"),
+					resource.TestCheckResourceAttr("replicate_deployment.test", "id", "replicate-testing/"+rName),
+				),
+			},
+			// Setting the owner to the same account is a no-op
+			{
+				Config:   testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_Hardware(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown SKUs fail the plan, suggesting the closest SKU
+			{
+				Config:      testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "gpu-t4x", 0, 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Did you mean "gpu-t4"\?`),
+			},
+			{
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "gpu-t4", 0, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "hardware", "gpu-t4"),
+				),
+			},
+			// Changing to an unknown SKU is caught too
+			{
+				Config:      testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "gpu-a40-medium", 0, 1),
+				ExpectError: regexp.MustCompile(`Invalid Hardware SKU`),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_HardwareUnavailable(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusForbidden})
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The plan succeeds without the hardware list, leaving the check to the API
+			{
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "hardware", "cpu"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_HardwareEmpty(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.hardware = []replicate.Hardware{}
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An empty hardware list skips validation instead of rejecting every SKU
+			{
+				Config:             testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_Version(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A version of a different model fails the plan
+			{
+				Config:      testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", fakeVersionID("stability-ai/sdxl:4"), "cpu", 0, 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Version Not Found`),
+			},
+			{
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+			},
+			// Changing to a version of a different model is caught too
+			{
+				Config:      testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", fakeVersionID("stability-ai/sdxl:3"), "cpu", 0, 1),
+				ExpectError: regexp.MustCompile(`Version Not Found`),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_VersionUnavailable(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Method: http.MethodGet, Path: "/models/replicate/hello-world/versions", Status: http.StatusForbidden})
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The plan succeeds without the version, leaving the check to the API
+			{
+				Config: testAccDeploymentResourceConfig(api, "replicate-testing", rName, "replicate/hello-world", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa", "cpu", 0, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "version", "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_UnknownVersion(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A version that isn't known until apply is left for the API to check
+			{
+				Config: fmt.Sprintf(testAccProviderConfig(api)+`
+resource "terraform_data" "version" {
+  input = %[2]q
+}
+
+resource "replicate_deployment" "test" {
+  name          = %[1]q
+  model         = "stability-ai/sdxl"
+  version       = terraform_data.version.output
+  hardware      = "gpu-a40-large"
+  min_instances = 0
+  max_instances = 1
+}
+`, rName, fakeVersionID("stability-ai/sdxl:4")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "version", fakeVersionID("stability-ai/sdxl:4")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResource_Timeouts(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	api.injectFault(fakeFault{Method: http.MethodPost, Path: "/deployments", Delay: 500 * time.Millisecond, Times: 1})
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccProviderConfig(api)+`
+resource "replicate_deployment" "test" {
+  name          = %[1]q
+  model         = "replicate/hello-world"
+  version       = "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"
+  hardware      = "cpu"
+  min_instances = 0
+  max_instances = 1
+
+  timeouts {
+    create = "100ms"
+  }
+}
+`, rName),
+				ExpectError: regexp.MustCompile(`(?s)Timeout Exceeded.*Increase timeouts.create`),
+			},
+		},
+	})
+}
+
+func TestAddDeploymentClientError(t *testing.T) {
+	var diags diag.Diagnostics
+	addDeploymentClientError(context.Background(), &diags, "read", time.Minute, errors.New("boom"))
+	if summary := diags.Errors()[0].Summary(); summary != "Client Error" {
+		t.Errorf("expected a client error, got %q", summary)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	diags = nil
+	addDeploymentClientError(ctx, &diags, "update", 20*time.Minute, ctx.Err())
+	if summary := diags.Errors()[0].Summary(); summary != "Timeout Exceeded" {
+		t.Errorf("expected a timeout error, got %q", summary)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "20m0s") || !strings.Contains(detail, "timeouts.update") {
+		t.Errorf("expected the timeout to be described, got %q", detail)
+	}
+}
+
+func TestAccDeploymentResource_TimeoutsOnlyUpdate(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	config := func(timeout string) string {
+		return fmt.Sprintf(testAccProviderConfig(api)+`
+resource "replicate_deployment" "test" {
+  name          = %[1]q
+  model         = "replicate/hello-world"
+  version       = "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa"
+  hardware      = "cpu"
+  min_instances = 0
+  max_instances = 1
+
+  timeouts {
+    update = %[2]q
+  }
+}
+`, rName, timeout)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("10m"),
+			},
+			// Changing only Terraform settings doesn't create a new release
+			{
+				Config: config("20m"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("replicate_deployment.test", tfjsonpath.New("current_release").AtMapKey("number"), knownvalue.Int64Exact(1)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_deployment.test", "current_release.number", "1"),
+					func(*terraform.State) error {
+						if count := api.requestCount(http.MethodPatch, "/deployments/replicate-testing/"+rName); count != 0 {
+							return fmt.Errorf("expected no updates, got %d", count)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestParseDeploymentID(t *testing.T) {
+	owner, name, err := parseDeploymentID("acme/image-generator")
+	if err != nil || owner != "acme" || name != "image-generator" {
+		t.Errorf("unexpected result: %q, %q, %v", owner, name, err)
+	}
+
+	for _, id := range []string{"", "acme", "acme/", "/image-generator", "acme/image-generator/extra"} {
+		if _, _, err := parseDeploymentID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
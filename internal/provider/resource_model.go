@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicate/replicate-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ModelResource{}
+var _ resource.ResourceWithImportState = &ModelResource{}
+var _ resource.ResourceWithModifyPlan = &ModelResource{}
+
+func NewModelResource() resource.Resource {
+	return &ModelResource{}
+}
+
+// ModelResource defines the resource implementation.
+type ModelResource struct {
+	client *replicate.Client
+}
+
+// ModelResourceModel describes the resource data model.
+type ModelResourceModel struct {
+	Owner         types.String `tfsdk:"owner"`
+	Name          types.String `tfsdk:"name"`
+	Visibility    types.String `tfsdk:"visibility"`
+	Hardware      types.String `tfsdk:"hardware"`
+	Description   types.String `tfsdk:"description"`
+	GithubURL     types.String `tfsdk:"github_url"`
+	PaperURL      types.String `tfsdk:"paper_url"`
+	LicenseURL    types.String `tfsdk:"license_url"`
+	CoverImageURL types.String `tfsdk:"cover_image_url"`
+	URL           types.String `tfsdk:"url"`
+	Id            types.String `tfsdk:"id"`
+}
+
+func (r *ModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (r *ModelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// The Replicate API has no endpoint for updating a model,
+	// so every configurable attribute forces a new model when it changes.
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Model resource. Replicate does not support updating models in place, so changing any attribute replaces the model. A model can only be deleted once all of its versions have been deleted, so planning to replace a model that has versions fails.",
+
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Owner of the model (user or organization name)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the model",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Whether the model is `public` or `private`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("public", "private"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware": schema.StringAttribute{
+				MarkdownDescription: "Hardware SKU to run the model on",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					// The API doesn't return the hardware of a model,
+					// so an imported model only learns it from configuration.
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the hardware requires replacing the model, unless the model was imported.",
+						"Changing the hardware requires replacing the model, unless the model was imported.",
+					),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the model. Changing this replaces the model, which fails once the model has versions.",
+				Optional:            true,
+				Validators: []validator.String{
+					// The API doesn't return empty values, so "" would be read back as null
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"github_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's source code on GitHub. Changing this replaces the model, which fails once the model has versions.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"paper_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the paper describing the model. Changing this replaces the model, which fails once the model has versions.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"license_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's license. Changing this replaces the model, which fails once the model has versions.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cover_image_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model's cover image. Changing this replaces the model, which fails once the model has versions.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL of the model on Replicate",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when creating or destroying, or before the provider is configured
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state ModelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !modelReplaced(plan, state) {
+		return
+	}
+
+	// Replacing a model deletes it, which the API refuses once it has versions
+	model, err := r.client.GetModel(ctx, state.Owner.ValueString(), state.Name.ValueString())
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		// The API checks for versions again when deleting, so don't fail the plan
+		tflog.Warn(ctx, "unable to read model, skipping version check", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if model.LatestVersion != nil {
+		resp.Diagnostics.AddError(
+			"Model Has Versions",
+			fmt.Sprintf("This change replaces model %q, but the API only deletes a model once all of its versions have been deleted. "+
+				"Revert the change, or delete the model's versions on Replicate first.", state.Id.ValueString()),
+		)
+	}
+}
+
+// modelReplaced reports whether applying plan over state replaces the model,
+// matching the attributes' plan modifiers.
+func modelReplaced(plan, state ModelResourceModel) bool {
+	return !plan.Owner.Equal(state.Owner) || !plan.Name.Equal(state.Name) || !plan.Visibility.Equal(state.Visibility) ||
+		(!state.Hardware.IsNull() && !plan.Hardware.Equal(state.Hardware)) ||
+		!plan.Description.Equal(state.Description) || !plan.GithubURL.Equal(state.GithubURL) || !plan.PaperURL.Equal(state.PaperURL) ||
+		!plan.LicenseURL.Equal(state.LicenseURL) || !plan.CoverImageURL.Equal(state.CoverImageURL)
+}
+
+func (r *ModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ModelResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create model with API
+	model, err := r.client.CreateModel(ctx, data.Owner.ValueString(), data.Name.ValueString(), replicate.CreateModelOptions{
+		Visibility:    data.Visibility.ValueString(),
+		Hardware:      data.Hardware.ValueString(),
+		Description:   data.Description.ValueStringPointer(),
+		GithubURL:     data.GithubURL.ValueStringPointer(),
+		PaperURL:      data.PaperURL.ValueStringPointer(),
+		LicenseURL:    data.LicenseURL.ValueStringPointer(),
+		CoverImageURL: data.CoverImageURL.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create model, got error: %s", err))
+		return
+	}
+
+	// Update the model with the latest data
+	data.setModel(model)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ModelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owner, name, err := parseModelID(data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", err.Error())
+		return
+	}
+
+	// Get model from API
+	model, err := r.client.GetModel(ctx, owner, name)
+	if isNotFound(err) {
+		// The model was deleted outside of Terraform, so plan to recreate it
+		tflog.Warn(ctx, "model not found, removing from state", map[string]interface{}{"id": data.Id.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		return
+	}
+
+	// Update the model with the latest data
+	data.setModel(model)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ModelResourceModel
+
+	// Every attribute other than hardware on an imported model requires
+	// replacement, so there is nothing to send to the API here.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ModelResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteModel(ctx, data.Owner.ValueString(), data.Name.ValueString())
+	if isNotFound(err) {
+		// Already deleted, which is the desired outcome
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete model, got error: %s", err))
+		return
+	}
+}
+
+func (r *ModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, _, err := parseModelID(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Models are imported by {owner}/{name}, for example \"acme/image-generator\", got: %q.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// parseModelID splits a model ID into its owner and name.
+func parseModelID(id string) (owner, name string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected ID in format owner/name, got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (r *ModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*ReplicateProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ReplicateProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// setModel copies the fields returned by the API into the resource model.
+// Hardware isn't part of the API response, so it's left untouched.
+func (data *ModelResourceModel) setModel(model *replicate.Model) {
+	data.Owner = types.StringValue(model.Owner)
+	data.Name = types.StringValue(model.Name)
+	data.Visibility = types.StringValue(model.Visibility)
+	data.Description = optionalStringValue(model.Description)
+	data.GithubURL = optionalStringValue(model.GithubURL)
+	data.PaperURL = optionalStringValue(model.PaperURL)
+	data.LicenseURL = optionalStringValue(model.LicenseURL)
+	data.CoverImageURL = optionalStringValue(model.CoverImageURL)
+	data.URL = types.StringValue(model.URL)
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s", model.Owner, model.Name))
+}
+
+// optionalStringValue returns a null string for empty API values,
+// so that unset optional attributes don't show a diff after refresh.
+func optionalStringValue(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
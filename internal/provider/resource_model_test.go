@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/replicate/replicate-go"
+)
+
+func TestAccModelResource(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccModelResourceConfig(api, "replicate-testing", rName, "private", "A model managed by Terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_model.test", "owner", "replicate-testing"),
+					resource.TestCheckResourceAttr("replicate_model.test", "name", rName),
+					resource.TestCheckResourceAttr("replicate_model.test", "visibility", "private"),
+					resource.TestCheckResourceAttr("replicate_model.test", "hardware", "cpu"),
+					resource.TestCheckResourceAttr("replicate_model.test", "description", "A model managed by Terraform"),
+					resource.TestCheckResourceAttr("replicate_model.test", "id", "replicate-testing/"+rName),
+					resource.TestCheckResourceAttrSet("replicate_model.test", "url"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "replicate_model.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API doesn't return the hardware of a model.
+				ImportStateVerifyIgnore: []string{"hardware"},
+			},
+			// Replace testing
+			{
+				Config: testAccModelResourceConfig(api, "replicate-testing", rName, "private", "An updated description"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("replicate_model.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicate_model.test", "description", "An updated description"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccModelResource_ReplaceWithVersions(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeAPI(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModelResourceConfig(api, "replicate-testing", rName, "private", "A model managed by Terraform"),
+			},
+			// The model can't be deleted, so the plan fails instead of apply
+			{
+				PreConfig: func() {
+					api.setModelVersions("replicate-testing", rName, replicate.ModelVersion{ID: fakeVersionID(rName)})
+				},
+				Config:      testAccModelResourceConfig(api, "replicate-testing", rName, "private", "An updated description"),
+				ExpectError: regexp.MustCompile(`Model Has Versions`),
+			},
+			// Once the versions are gone the model can be replaced, and deleted at the end of the test
+			{
+				PreConfig: func() {
+					api.setModelVersions("replicate-testing", rName)
+				},
+				Config: testAccModelResourceConfig(api, "replicate-testing", rName, "private", "An updated description"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("replicate_model.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccModelResource_EmptyDescription(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccModelResourceConfig(api, "replicate-testing", rName, "private", ""),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func TestParseModelID(t *testing.T) {
+	owner, name, err := parseModelID("acme/image-generator")
+	if err != nil || owner != "acme" || name != "image-generator" {
+		t.Errorf("unexpected result: %q, %q, %v", owner, name, err)
+	}
+
+	for _, id := range []string{"", "acme", "acme/", "/image-generator", "acme/image-generator/extra"} {
+		if _, _, err := parseModelID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func testAccModelResourceConfig(api *fakeReplicateAPI, owner, name, visibility, description string) string {
+	return fmt.Sprintf(testAccProviderConfig(api)+`
+resource "replicate_model" "test" {
+  owner       = %[1]q
+  name        = %[2]q
+  visibility  = %[3]q
+  hardware    = "cpu"
+  description = %[4]q
+}
+`, owner, name, visibility, description)
+}
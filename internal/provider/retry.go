@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryMinBackoff  = 500 * time.Millisecond
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryModel describes the provider's retry block.
+type RetryModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff           types.String `tfsdk:"min_backoff"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+}
+
+// retryTransport retries requests that fail with a retryable status code,
+// waiting for the Retry-After header if the API sends one, and otherwise
+// backing off exponentially.
+//
+// The client's own retries are disabled, since they can't be configured
+// beyond the number of attempts, don't rewind request bodies, and sleep for
+// Retry-After even when no retries are left.
+type retryTransport struct {
+	next        http.RoundTripper
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	statusCodes map[int]bool
+}
+
+// newRetryTransport configures a retryTransport from the provider's retry block,
+// using the defaults for anything that isn't set.
+func newRetryTransport(next http.RoundTripper, data *RetryModel) (*retryTransport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	t := &retryTransport{
+		next:        next,
+		maxAttempts: defaultRetryMaxAttempts,
+		minBackoff:  defaultRetryMinBackoff,
+		maxBackoff:  defaultRetryMaxBackoff,
+		statusCodes: map[int]bool{},
+	}
+	statusCodes := defaultRetryStatusCodes
+
+	if data == nil {
+		for _, code := range statusCodes {
+			t.statusCodes[code] = true
+		}
+		return t, diags
+	}
+
+	unknown := func(attribute string) {
+		diags.AddAttributeError(
+			path.Root("retry").AtName(attribute),
+			"Unknown Retry Configuration",
+			fmt.Sprintf("The provider cannot configure retries because the %s value is not yet known. "+
+				"Either apply the source of the value first, or set the value statically in the configuration.", attribute),
+		)
+	}
+
+	if data.MaxAttempts.IsUnknown() {
+		unknown("max_attempts")
+	} else if !data.MaxAttempts.IsNull() {
+		t.maxAttempts = int(data.MaxAttempts.ValueInt64())
+	}
+
+	parseDuration := func(value types.String, attribute string, d *time.Duration) {
+		if value.IsUnknown() {
+			unknown(attribute)
+			return
+		}
+		if value.IsNull() {
+			return
+		}
+		parsed, err := time.ParseDuration(value.ValueString())
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName(attribute),
+				"Invalid Duration",
+				fmt.Sprintf("Expected a non-negative duration like \"500ms\" or \"2s\", got: %s", value.ValueString()),
+			)
+			return
+		}
+		*d = parsed
+	}
+	parseDuration(data.MinBackoff, "min_backoff", &t.minBackoff)
+	parseDuration(data.MaxBackoff, "max_backoff", &t.maxBackoff)
+
+	if !diags.HasError() && t.minBackoff > t.maxBackoff {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("max_backoff"),
+			"Invalid Backoff",
+			fmt.Sprintf("max_backoff (%s) must not be less than min_backoff (%s).", t.maxBackoff, t.minBackoff),
+		)
+	}
+
+	if data.RetryableStatusCodes.IsUnknown() {
+		unknown("retryable_status_codes")
+	} else if !data.RetryableStatusCodes.IsNull() {
+		var codes []int64
+		diags.Append(data.RetryableStatusCodes.ElementsAs(context.Background(), &codes, false)...)
+		statusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			statusCodes = append(statusCodes, int(code))
+		}
+	}
+
+	for _, code := range statusCodes {
+		t.statusCodes[code] = true
+	}
+
+	return t, diags
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		retry := t.shouldRetry(req, resp, attempt)
+		delay := t.backoff(attempt, resp)
+		// Waiting past the deadline would only replace the API's error with a timeout
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			retry = false
+		}
+		if !retry {
+			// The client sleeps for Retry-After before its own retry, even
+			// when it has no retries left, so don't pass the header on.
+			resp.Header.Del("Retry-After")
+			return resp, nil
+		}
+
+		tflog.Info(ctx, "retrying Replicate API request", map[string]interface{}{
+			"method":       req.Method,
+			"url":          req.URL.String(),
+			"status":       resp.StatusCode,
+			"attempt":      attempt,
+			"max_attempts": t.maxAttempts,
+			"delay":        delay.String(),
+		})
+
+		// Drain the body, so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether a failed request can be sent again. Like the
+// client's own policy, 429 is retried for every method, since the API
+// rejected the request without acting on it, while other status codes are
+// only retried for GET and HEAD. A POST, PATCH or DELETE that failed with a
+// server error may still have taken effect, and retrying it could fail with
+// a conflict or not found error instead.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, attempt int) bool {
+	if !t.statusCodes[resp.StatusCode] || attempt >= t.maxAttempts {
+		return false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	// Requests whose body can't be sent again are never retried
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// backoff returns how long to wait before the next attempt: the Retry-After
+// header if the API sent one, otherwise min_backoff doubled for each attempt
+// so far. Either way, the wait is at most max_backoff.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return min(max(time.Duration(seconds)*time.Second, 0), t.maxBackoff)
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return min(max(time.Until(date), 0), t.maxBackoff)
+		}
+	}
+
+	delay := t.minBackoff
+	for i := 1; i < attempt && delay < t.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, t.maxBackoff)
+}
+
+// rewindRequest returns a copy of req with a fresh body for sending again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("unable to rewind request body for retry: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+)
+
+// testRetryClient returns a client that retries with the given transport settings.
+func testRetryClient(t *testing.T, api *fakeReplicateAPI, maxAttempts int, backoff time.Duration) *replicate.Client {
+	t.Helper()
+
+	client, err := replicate.NewClient(
+		replicate.WithToken(fakeAPIToken),
+		replicate.WithBaseURL(api.URL),
+		replicate.WithHTTPClient(&http.Client{
+			Transport: &retryTransport{
+				next:        http.DefaultTransport,
+				maxAttempts: maxAttempts,
+				minBackoff:  backoff,
+				maxBackoff:  backoff,
+				statusCodes: map[int]bool{http.StatusTooManyRequests: true, http.StatusBadGateway: true},
+			},
+		}),
+		replicate.WithRetryPolicy(0, &replicate.ConstantBackoff{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestRetryTransport(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 3, time.Millisecond)
+	ctx := context.Background()
+
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 1})
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusBadGateway, Times: 1})
+	if _, err := client.GetCurrentAccount(ctx); err != nil {
+		t.Fatalf("expected retries to succeed, got %s", err)
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 3 {
+		t.Errorf("expected 3 requests, got %d", count)
+	}
+
+	// Other status codes aren't retried
+	api.injectFault(fakeFault{Path: "/hardware", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := client.ListHardware(ctx); err == nil {
+		t.Error("expected an error")
+	}
+	if count := api.requestCount(http.MethodGet, "/hardware"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestRetryTransport_Body(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 2, time.Millisecond)
+
+	// The request body is sent again on retry
+	api.injectFault(fakeFault{Method: http.MethodPost, Path: "/deployments", Status: http.StatusTooManyRequests, Times: 1})
+	deployment, err := client.CreateDeployment(context.Background(), replicate.CreateDeploymentOptions{
+		Name:         "retried",
+		Model:        "replicate/hello-world",
+		Version:      "5c7d5dc6dd8bf75c1acaa8565735e7986bc5b66206b55cca93cb72c9bf15ccaa",
+		Hardware:     "cpu",
+		MinInstances: 0,
+		MaxInstances: 1,
+	})
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %s", err)
+	}
+	if deployment.Name != "retried" {
+		t.Errorf("unexpected deployment: %+v", deployment)
+	}
+}
+
+func TestRetryTransport_Methods(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 3, time.Millisecond)
+	ctx := context.Background()
+
+	// A server error may come after the deployment was created, so the
+	// request isn't sent again
+	api.injectFault(fakeFault{Method: http.MethodPost, Path: "/deployments", Status: http.StatusBadGateway, Times: 1})
+	if _, err := client.CreateDeployment(ctx, replicate.CreateDeploymentOptions{Name: "unretried"}); err == nil {
+		t.Error("expected an error")
+	}
+	if count := api.requestCount(http.MethodPost, "/deployments"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+
+	api.injectFault(fakeFault{Method: http.MethodDelete, Path: "/deployments", Status: http.StatusBadGateway, Times: 1})
+	if err := client.DeleteDeployment(ctx, "replicate-testing", "unretried"); err == nil {
+		t.Error("expected an error")
+	}
+	if count := api.requestCount(http.MethodDelete, "/deployments/replicate-testing/unretried"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestRetryTransport_Exhausted(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 2, time.Millisecond)
+
+	// The client doesn't wait for Retry-After once retries are exhausted
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusTooManyRequests, RetryAfter: "1"})
+	start := time.Now()
+	_, err := client.GetCurrentAccount(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Retry-After to be capped by max_backoff and not repeated by the client, took %s", elapsed)
+	}
+}
+
+func TestRetryTransport_Deadline(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 2, time.Hour)
+
+	// The API's error is returned straight away, rather than a timeout
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusBadGateway})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetCurrentAccount(ctx)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the API error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected no wait, took %s", elapsed)
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestRetryTransport_Canceled(t *testing.T) {
+	api := newFakeReplicateAPI(t)
+	client := testRetryClient(t, api, 2, time.Hour)
+
+	api.injectFault(fakeFault{Path: "/account", Status: http.StatusBadGateway})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := client.GetCurrentAccount(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait to be canceled, got %v", err)
+	}
+	if count := api.requestCount(http.MethodGet, "/account"); count != 1 {
+		t.Errorf("expected 1 request, got %d", count)
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	transport := &retryTransport{minBackoff: 100 * time.Millisecond, maxBackoff: 350 * time.Millisecond}
+
+	for attempt, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 350 * time.Millisecond,
+		9: 350 * time.Millisecond,
+	} {
+		if actual := transport.backoff(attempt, &http.Response{Header: http.Header{}}); actual != expected {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, expected, actual)
+		}
+	}
+
+	// Retry-After takes precedence, up to max_backoff
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"0"}}}
+	if actual := transport.backoff(2, resp); actual != 0 {
+		t.Errorf("expected Retry-After in seconds, got %s", actual)
+	}
+
+	resp.Header.Set("Retry-After", "2")
+	if actual := transport.backoff(1, resp); actual != 350*time.Millisecond {
+		t.Errorf("expected Retry-After to be capped at max_backoff, got %s", actual)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if actual := transport.backoff(1, resp); actual != 350*time.Millisecond {
+		t.Errorf("expected a Retry-After date to be capped at max_backoff, got %s", actual)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if actual := transport.backoff(1, resp); actual != 0 {
+		t.Errorf("expected no delay for a past Retry-After date, got %s", actual)
+	}
+
+	resp.Header.Set("Retry-After", "soon")
+	if actual := transport.backoff(2, resp); actual != 200*time.Millisecond {
+		t.Errorf("expected invalid Retry-After to be ignored, got %s", actual)
+	}
+}
+
+func TestNewRetryTransport_Defaults(t *testing.T) {
+	transport, diags := newRetryTransport(http.DefaultTransport, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if transport.maxAttempts != defaultRetryMaxAttempts || transport.minBackoff != defaultRetryMinBackoff || transport.maxBackoff != defaultRetryMaxBackoff {
+		t.Errorf("unexpected defaults: %+v", transport)
+	}
+	for _, code := range defaultRetryStatusCodes {
+		if !transport.statusCodes[code] {
+			t.Errorf("expected %d to be retried by default", code)
+		}
+	}
+}